@@ -0,0 +1,202 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CellRange is a rectangular region of an array given by the 0-based indices
+// of its inclusive corners, as parsed from a spreadsheet range such as "A1:C3".
+type CellRange struct {
+	Row1, Col1, Row2, Col2 int
+}
+
+// String returns the range in A1 style, such as "A1:C3", or as a single cell
+// reference if both corners are the same cell.
+func (r CellRange) String() string {
+	if r.Row1 == r.Row2 && r.Col1 == r.Col2 {
+		return FormatA1(r.Row1, r.Col1)
+	}
+	return FormatA1(r.Row1, r.Col1) + ":" + FormatA1(r.Row2, r.Col2)
+}
+
+// ParseA1 converts an A1-style cell reference such as "B3" or "$AA$10" into
+// 0-based row and column indices. Column letters are case-insensitive and the
+// "$" markers of absolute references are ignored.
+// It returns ErrReference if ref is not a valid A1 reference.
+func ParseA1(ref string) (row, col int, err error) {
+	s := strings.TrimPrefix(ref, "$")
+	i := 0
+	for i < len(s) && isLetter(s[i]) {
+		if col > (maxIndex-26)/26 {
+			return 0, 0, fmt.Errorf("%w: column of %q out of range", ErrReference, ref)
+		}
+		col = col*26 + int(upper(s[i])-'A'+1)
+		i++
+	}
+	if i == 0 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrReference, ref)
+	}
+	row, ok := parseRefNumber(strings.TrimPrefix(s[i:], "$"))
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %q", ErrReference, ref)
+	}
+	return row - 1, col - 1, nil
+}
+
+// ParseR1C1 converts an absolute R1C1-style cell reference such as "R3C2"
+// into 0-based row and column indices. The letters are case-insensitive.
+// Relative references such as "R[-1]C" are not supported, since they have no
+// meaning without a current cell.
+// It returns ErrReference if ref is not a valid R1C1 reference.
+func ParseR1C1(ref string) (row, col int, err error) {
+	if len(ref) < 4 || upper(ref[0]) != 'R' {
+		return 0, 0, fmt.Errorf("%w: %q", ErrReference, ref)
+	}
+	i := strings.IndexAny(ref, "Cc")
+	if i < 0 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrReference, ref)
+	}
+	row, okRow := parseRefNumber(ref[1:i])
+	col, okCol := parseRefNumber(ref[i+1:])
+	if !okRow || !okCol {
+		return 0, 0, fmt.Errorf("%w: %q", ErrReference, ref)
+	}
+	return row - 1, col - 1, nil
+}
+
+// ParseRange converts a range such as "A1:C3" or "R1C1:R3C3" into the 0-based
+// corners of the region. Each corner may use either style, and a single cell
+// reference such as "B2" yields a range of one cell. The corners are kept in
+// the order given; Fill and SubArray accept them in any order.
+// It returns ErrReference if ref is not a valid range.
+func ParseRange(ref string) (CellRange, error) {
+	first, second, isRange := strings.Cut(ref, ":")
+	row1, col1, err := parseRef(first)
+	if err != nil {
+		return CellRange{}, err
+	}
+	row2, col2 := row1, col1
+	if isRange {
+		if row2, col2, err = parseRef(second); err != nil {
+			return CellRange{}, err
+		}
+	}
+	return CellRange{Row1: row1, Col1: col1, Row2: row2, Col2: col2}, nil
+}
+
+// FormatA1 returns the A1-style reference, such as "B3", of the cell at the
+// 0-based indices row and col.
+func FormatA1(row, col int) string {
+	var buf [16]byte
+	i := len(buf)
+	for col++; col > 0; col = (col - 1) / 26 {
+		i--
+		buf[i] = byte('A' + (col-1)%26)
+	}
+	return string(buf[i:]) + strconv.Itoa(row+1)
+}
+
+// FormatR1C1 returns the R1C1-style reference, such as "R3C2", of the cell at
+// the 0-based indices row and col.
+func FormatR1C1(row, col int) string {
+	return "R" + strconv.Itoa(row+1) + "C" + strconv.Itoa(col+1)
+}
+
+// GetA1 returns the value of the cell at an A1-style reference such as "B3".
+// It returns an error wrapping ErrReference if ref is malformed and
+// ErrOutOfBounds if the cell is outside the array.
+func (a Array2D[T]) GetA1(ref string) (T, error) {
+	row, col, err := ParseA1(ref)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if v, ok := a.Get(row, col); ok {
+		return v, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("%w: %s outside %dx%d array", ErrOutOfBounds, ref, a.height, a.width)
+}
+
+// SetA1 sets the value of the cell at an A1-style reference such as "AA10",
+// with the same errors as GetA1.
+func (a Array2D[T]) SetA1(ref string, value T) error {
+	row, col, err := ParseA1(ref)
+	if err != nil {
+		return err
+	}
+	return a.Set(row, col, value)
+}
+
+// GetR1C1 is like Get but takes 1-based indices, as in R1C1 references and
+// spreadsheet formulas, so GetR1C1(1, 1) returns the first cell.
+func (a Array2D[T]) GetR1C1(row, col int) (T, bool) {
+	return a.Get(row-1, col-1)
+}
+
+// SetR1C1 is like Set but takes 1-based indices, as in R1C1 references and
+// spreadsheet formulas, so SetR1C1(1, 1, v) sets the first cell.
+func (a Array2D[T]) SetR1C1(row, col int, value T) error {
+	return a.Set(row-1, col-1, value)
+}
+
+// SubArrayA1 is like SubArray but takes the region as a range string accepted
+// by ParseRange, such as "B2:D4".
+func (a Array2D[T]) SubArrayA1(ref string) (Array2D[T], error) {
+	r, err := ParseRange(ref)
+	if err != nil {
+		return Array2D[T]{}, err
+	}
+	return a.SubArray(r.Row1, r.Col1, r.Row2, r.Col2)
+}
+
+// FillA1 is like Fill but takes the region as a range string accepted by
+// ParseRange, such as "A1:C3".
+func (a Array2D[T]) FillA1(ref string, value T) error {
+	r, err := ParseRange(ref)
+	if err != nil {
+		return err
+	}
+	return a.Fill(r.Row1, r.Col1, r.Row2, r.Col2, value)
+}
+
+// maxIndex bounds the numbers accepted in references so that converting them
+// to indices cannot overflow int.
+const maxIndex = int(^uint(0) >> 1)
+
+// parseRef parses a cell reference in either A1 or R1C1 style.
+func parseRef(ref string) (row, col int, err error) {
+	if row, col, err = ParseA1(ref); err == nil {
+		return row, col, nil
+	}
+	if row, col, errR1C1 := ParseR1C1(ref); errR1C1 == nil {
+		return row, col, nil
+	}
+	return 0, 0, err
+}
+
+// parseRefNumber parses a positive decimal row or column number, rejecting
+// signs and other forms strconv.Atoi would accept.
+func parseRefNumber(s string) (int, bool) {
+	if s == "" || s[0] < '1' || s[0] > '9' {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+func isLetter(b byte) bool {
+	return b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z'
+}
+
+func upper(b byte) byte {
+	if b >= 'a' {
+		return b - 'a' + 'A'
+	}
+	return b
+}
@@ -0,0 +1,121 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseA1(t *testing.T) {
+	tests := []struct {
+		ref      string
+		row, col int
+	}{
+		{"A1", 0, 0},
+		{"b3", 2, 1},
+		{"Z26", 25, 25},
+		{"AA10", 9, 26},
+		{"$AB$2", 1, 27},
+		{"XFD1048576", 1048575, 16383},
+	}
+	for _, tt := range tests {
+		row, col, err := ParseA1(tt.ref)
+		if err != nil || row != tt.row || col != tt.col {
+			t.Errorf("ParseA1(%q): want (%d, %d), got (%d, %d, %v)", tt.ref, tt.row, tt.col, row, col, err)
+		}
+		if tt.ref[0] != '$' && tt.ref[0] < 'a' {
+			if got := FormatA1(row, col); got != tt.ref {
+				t.Errorf("FormatA1(%d, %d): want %q, got %q", row, col, tt.ref, got)
+			}
+		}
+	}
+	for _, ref := range []string{"", "A", "1", "A0", "A+1", "A01", "1A", "A1B", "R1C1", "AAAAAAAAAAAAAAAAAAAAAA1"} {
+		if _, _, err := ParseA1(ref); !errors.Is(err, ErrReference) {
+			t.Errorf("ParseA1(%q): want ErrReference, got %v", ref, err)
+		}
+	}
+}
+
+func TestParseR1C1(t *testing.T) {
+	row, col, err := ParseR1C1("r3c12")
+	if err != nil || row != 2 || col != 11 {
+		t.Errorf("ParseR1C1(%q): want (2, 11), got (%d, %d, %v)", "r3c12", row, col, err)
+	}
+	if got := FormatR1C1(2, 11); got != "R3C12" {
+		t.Errorf("FormatR1C1(2, 11): want %q, got %q", "R3C12", got)
+	}
+	for _, ref := range []string{"", "R1", "RC", "R0C1", "R[1]C1", "R1C", "A1"} {
+		if _, _, err := ParseR1C1(ref); !errors.Is(err, ErrReference) {
+			t.Errorf("ParseR1C1(%q): want ErrReference, got %v", ref, err)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want CellRange
+		str  string
+	}{
+		{"A1:C3", CellRange{0, 0, 2, 2}, "A1:C3"},
+		{"B2", CellRange{1, 1, 1, 1}, "B2"},
+		{"R1C2:B3", CellRange{0, 1, 2, 1}, "B1:B3"},
+		{"C3:A1", CellRange{2, 2, 0, 0}, "C3:A1"},
+	}
+	for _, tt := range tests {
+		got, err := ParseRange(tt.ref)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRange(%q): want %v, got %v, %v", tt.ref, tt.want, got, err)
+		}
+		if s := got.String(); s != tt.str {
+			t.Errorf("String(): want %q, got %q", tt.str, s)
+		}
+	}
+	for _, ref := range []string{"", ":", "A1:", ":B2", "A1:B2:C3"} {
+		if _, err := ParseRange(ref); !errors.Is(err, ErrReference) {
+			t.Errorf("ParseRange(%q): want ErrReference, got %v", ref, err)
+		}
+	}
+}
+
+func TestA1Access(t *testing.T) {
+	a, _ := FromSlice(3, 3, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, true)
+
+	if v, err := a.GetA1("C2"); err != nil || v != 8 {
+		t.Errorf("GetA1(C2): want 8, got %d, %v", v, err)
+	}
+	if err := a.SetA1("A3", 0); err != nil {
+		t.Fatalf("SetA1() returned an unexpected error: %v", err)
+	}
+	if v, ok := a.GetR1C1(3, 1); !ok || v != 0 {
+		t.Errorf("GetR1C1(3, 1): want 0, got %d, %v", v, ok)
+	}
+	if err := a.SetR1C1(1, 1, -1); err != nil {
+		t.Fatalf("SetR1C1() returned an unexpected error: %v", err)
+	}
+	if _, ok := a.GetR1C1(0, 1); ok {
+		t.Errorf("GetR1C1(0, 1): want out of bounds")
+	}
+	if _, err := a.GetA1("D1"); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("want error to be ErrOutOfBounds, but it was not. got: %v", err)
+	}
+	if err := a.SetA1("1A", 0); !errors.Is(err, ErrReference) {
+		t.Errorf("want error to be ErrReference, but it was not. got: %v", err)
+	}
+
+	sub, err := a.SubArrayA1("B2:C3")
+	if err != nil {
+		t.Fatalf("SubArrayA1() returned an unexpected error: %v", err)
+	}
+	if want := "Array2d[int] 2x2 [[5 8] [6 9]]"; sub.String() != want {
+		t.Errorf("SubArrayA1(): want %q, got %q", want, sub.String())
+	}
+	if err := a.FillA1("R1C2:R2C3", 7); err != nil {
+		t.Fatalf("FillA1() returned an unexpected error: %v", err)
+	}
+	if want := "Array2d[int] 3x3 [[-1 7 7] [2 7 7] [0 6 9]]"; a.String() != want {
+		t.Errorf("FillA1(): want %q, got %q", want, a.String())
+	}
+}
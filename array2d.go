@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unsafe"
 )
 
 var (
@@ -24,6 +25,21 @@ var (
 
 	// ErrDestLength is returned by Scan when the destination slice has an incorrect length.
 	ErrDestLength = errors.New("array2d: destination slice has incorrect length")
+
+	// ErrInvalidAxis is returned when an axis argument is neither 0 (rows) nor 1 (columns).
+	ErrInvalidAxis = errors.New("array2d: invalid axis")
+
+	// ErrPermutation is returned when a slice of indices is not a permutation
+	// of 0..n-1 for the dimension it reorders.
+	ErrPermutation = errors.New("array2d: invalid permutation")
+
+	// ErrFormat is returned when encoded array data is malformed or uses an
+	// unsupported version.
+	ErrFormat = errors.New("array2d: invalid encoded data")
+
+	// ErrReference is returned when a spreadsheet cell or range reference
+	// such as "B3" or "A1:C3" is malformed.
+	ErrReference = errors.New("array2d: invalid cell reference")
 )
 
 const (
@@ -41,12 +57,7 @@ func New[T any](height, width int, colMajor ...bool) Array2D[T] {
 	if len(colMajor) > 0 {
 		isColMajor = colMajor[0]
 	}
-	return Array2D[T]{
-		height:   height,
-		width:    width,
-		slice:    make([]T, width*height),
-		colMajor: isColMajor,
-	}
+	return newArray(height, width, make([]T, width*height), isColMajor)
 }
 
 // NewFilled initializes a 2-dimensional array with a value.
@@ -59,12 +70,23 @@ func NewFilled[T any](height, width int, value T, colMajor ...bool) Array2D[T] {
 	}
 	slice := make([]T, width*height)
 	fill(slice, value)
-	return Array2D[T]{
-		height:   height,
-		width:    width,
-		slice:    slice,
-		colMajor: isColMajor,
+	return newArray(height, width, slice, isColMajor)
+}
+
+// Checkerboard initializes a row-major 2-dimensional array with an alternating
+// pattern: cells where (row+col)%2 == 0 hold a and all other cells hold b.
+func Checkerboard[T any](height, width int, a, b T) Array2D[T] {
+	arr := New[T](height, width)
+	for r := 0; r < height; r++ {
+		for c := 0; c < width; c++ {
+			if (r+c)%2 == 0 {
+				arr.setUnchecked(r, c, a)
+			} else {
+				arr.setUnchecked(r, c, b)
+			}
+		}
 	}
+	return arr
 }
 
 // FromSlice creates a 2-dimensional array from the given slice. The length of
@@ -83,12 +105,7 @@ func FromSlice[T any](height, width int, slice []T, colMajor ...bool) (Array2D[T
 	if len(slice) != width*height {
 		return Array2D[T]{}, fmt.Errorf("%w: slice length %d does not match height*width %d", ErrShape, len(slice), width*height)
 	}
-	return Array2D[T]{
-		height:   height,
-		width:    width,
-		slice:    slice,
-		colMajor: isColMajor,
-	}, nil
+	return newArray(height, width, slice, isColMajor), nil
 }
 
 // FromJagged creates a 2-dimensional array from a jagged slice.
@@ -122,6 +139,65 @@ func FromJagged[J ~[]S, S ~[]E, E any](height, width int, jagged J, colMajor ...
 	return arr, nil
 }
 
+// Concat joins the given arrays along the specified axis.
+// Axis 0 stacks the arrays vertically (their widths must match) and axis 1
+// stacks them horizontally (their heights must match). It returns ErrShape if
+// the non-concatenated dimension differs and ErrInvalidAxis for any other axis.
+//
+// The result uses the memory layout of the first array. Concatenating no
+// arrays returns an empty array.
+func Concat[T any](axis int, arrays ...Array2D[T]) (Array2D[T], error) {
+	if axis != 0 && axis != 1 {
+		return Array2D[T]{}, fmt.Errorf("%w: %d", ErrInvalidAxis, axis)
+	}
+	if len(arrays) == 0 {
+		return Array2D[T]{}, nil
+	}
+
+	height, width := arrays[0].height, arrays[0].width
+	for i, arr := range arrays[1:] {
+		if axis == 0 {
+			if arr.width != width {
+				return Array2D[T]{}, fmt.Errorf("%w: array %d width %d does not match width %d", ErrShape, i+1, arr.width, width)
+			}
+			height += arr.height
+		} else {
+			if arr.height != height {
+				return Array2D[T]{}, fmt.Errorf("%w: array %d height %d does not match height %d", ErrShape, i+1, arr.height, height)
+			}
+			width += arr.width
+		}
+	}
+
+	result := New[T](height, width, arrays[0].colMajor)
+	offset := 0
+	for _, arr := range arrays {
+		for r := 0; r < arr.height; r++ {
+			for c := 0; c < arr.width; c++ {
+				if axis == 0 {
+					result.setUnchecked(offset+r, c, arr.getUnchecked(r, c))
+				} else {
+					result.setUnchecked(r, offset+c, arr.getUnchecked(r, c))
+				}
+			}
+		}
+		if axis == 0 {
+			offset += arr.height
+		} else {
+			offset += arr.width
+		}
+	}
+	return result, nil
+}
+
+// JoinCols concatenates equal-height arrays horizontally into one array whose
+// width is the sum of their widths. It is equivalent to Concat with axis 1 and
+// returns ErrShape, naming the index of the offending array, if any height
+// differs from the first array's.
+func JoinCols[T any](arrays []Array2D[T]) (Array2D[T], error) {
+	return Concat(1, arrays...)
+}
+
 // ToSlices returns a slice of slices representation of the array, organized by rows.
 //
 // For row-major arrays, this is a zero-copy operation in terms of element data.
@@ -162,36 +238,378 @@ func (a Array2D[T]) ToSlicesByCol() [][]T {
 	return slices
 }
 
+// ToJagged returns a copy of the array as a slice of rows, the inverse of
+// FromJagged. If trim is not nil, it is called with each row and its result is
+// stored instead, which allows producing ragged rows (for example by dropping
+// trailing zero values). The returned rows never alias the array.
+func (a Array2D[T]) ToJagged(trim func(row []T) []T) [][]T {
+	if a.height == 0 {
+		return nil
+	}
+	jagged := make([][]T, a.height)
+	for r := range jagged {
+		row := make([]T, a.width)
+		for c := range row {
+			row[c] = a.getUnchecked(r, c)
+		}
+		if trim != nil {
+			row = trim(row)
+		}
+		jagged[r] = row
+	}
+	return jagged
+}
+
 // Map creates a new Array2D by applying a function to each element of the input array.
 // The new array will have the same dimensions and memory layout (row/column-major)
 // as the original. The mapping function f is applied to each element of type T
 // to produce an element of type U.
 func Map[T any, U any](a Array2D[T], f func(v T) U) Array2D[U] {
-	newSlice := make([]U, len(a.slice))
-	for i, v := range a.slice {
-		newSlice[i] = f(v)
+	result := newArray(a.height, a.width, make([]U, a.height*a.width), a.colMajor)
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		dst := result.line(i)
+		for j, v := range a.line(i) {
+			dst[j] = f(v)
+		}
+	}
+	return result
+}
+
+// MapIndexed is like Map, but f also receives the row and column of each
+// element, for transforms that depend on position. The new array has the same
+// dimensions and memory layout as a.
+func MapIndexed[T any, U any](a Array2D[T], f func(row, col int, v T) U) Array2D[U] {
+	result := newArray(a.height, a.width, make([]U, a.height*a.width), a.colMajor)
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			result.setUnchecked(r, c, f(r, c, a.getUnchecked(r, c)))
+		}
+	}
+	return result
+}
+
+// TryMapAll creates a new Array2D by applying a fallible function to every
+// element of the input array. Unlike stopping at the first failure, it visits
+// every cell: where fn returns an error the zero value of U is stored and the
+// error, wrapped with the cell's coordinates, is collected.
+//
+// The errors are returned in logical row-major order and are nil if every call
+// succeeded. The new array has the same dimensions and memory layout as a.
+func TryMapAll[T any, U any](a Array2D[T], fn func(T) (U, error)) (Array2D[U], []error) {
+	result := New[U](a.height, a.width, a.colMajor)
+	var errs []error
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			v, err := fn(a.getUnchecked(r, c))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("array2d: cell (%d, %d): %w", r, c, err))
+				continue
+			}
+			result.setUnchecked(r, c, v)
+		}
+	}
+	return result, errs
+}
+
+// Mask returns a boolean array with the same dimensions and memory layout as a,
+// in which each cell holds pred applied to the corresponding cell of a.
+func Mask[T any](a Array2D[T], pred func(T) bool) Array2D[bool] {
+	return Map(a, pred)
+}
+
+// Reduce folds every element of a into an accumulator, starting from init and
+// calling f(acc, v) for each element in logical row-major order.
+func Reduce[T any, U any](a Array2D[T], init U, f func(acc U, v T) U) U {
+	acc := init
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			acc = f(acc, a.getUnchecked(r, c))
+		}
+	}
+	return acc
+}
+
+// ReduceRows folds each row of a separately, from left to right, starting
+// from init. It returns one result per row.
+func ReduceRows[T any, U any](a Array2D[T], init U, f func(acc U, v T) U) []U {
+	result := make([]U, a.height)
+	for r := range result {
+		acc := init
+		for c := 0; c < a.width; c++ {
+			acc = f(acc, a.getUnchecked(r, c))
+		}
+		result[r] = acc
+	}
+	return result
+}
+
+// ReduceCols folds each column of a separately, from top to bottom, starting
+// from init. It returns one result per column.
+func ReduceCols[T any, U any](a Array2D[T], init U, f func(acc U, v T) U) []U {
+	result := make([]U, a.width)
+	for c := range result {
+		acc := init
+		for r := 0; r < a.height; r++ {
+			acc = f(acc, a.getUnchecked(r, c))
+		}
+		result[c] = acc
+	}
+	return result
+}
+
+// CountRows returns, for each row, the number of cells equal to target.
+// The result has length Height().
+func CountRows[T comparable](a Array2D[T], target T) []int {
+	counts := make([]int, a.height)
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if a.getUnchecked(r, c) == target {
+				counts[r]++
+			}
+		}
+	}
+	return counts
+}
+
+// CountCols returns, for each column, the number of cells equal to target.
+// The result has length Width().
+func CountCols[T comparable](a Array2D[T], target T) []int {
+	counts := make([]int, a.width)
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if a.getUnchecked(r, c) == target {
+				counts[c]++
+			}
+		}
+	}
+	return counts
+}
+
+// Histogram returns the number of cells holding each distinct value of a.
+func Histogram[T comparable](a Array2D[T]) map[T]int {
+	counts := make(map[T]int)
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		for _, v := range a.line(i) {
+			counts[v]++
+		}
+	}
+	return counts
+}
+
+// Sparsity returns the fraction of cells of a holding the zero value of T,
+// from 0.0 (no zero cells) to 1.0 (all cells zero). It returns 0 for an
+// empty array.
+func Sparsity[T comparable](a Array2D[T]) float64 {
+	if a.height == 0 || a.width == 0 {
+		return 0
+	}
+	var zero T
+	zeros := 0
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		for _, v := range a.line(i) {
+			if v == zero {
+				zeros++
+			}
+		}
+	}
+	return float64(zeros) / float64(a.height*a.width)
+}
+
+// Coord is the position of a cell in an array.
+type Coord struct {
+	Row, Col int
+}
+
+// Find returns the position of the first cell whose value satisfies pred,
+// scanning in logical row-major order (left to right, then top to bottom)
+// regardless of the memory layout. ok is false if no cell matches.
+func (a Array2D[T]) Find(pred func(v T) bool) (row, col int, ok bool) {
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if pred(a.getUnchecked(r, c)) {
+				return r, c, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// FindAll returns the coordinates of every cell whose value satisfies pred,
+// in logical row-major order, the same order in which Find scans.
+func FindAll[T any](a Array2D[T], pred func(T) bool) []Coord {
+	var found []Coord
+	if a.colMajor {
+		for r := 0; r < a.height; r++ {
+			for c := 0; c < a.width; c++ {
+				if pred(a.getUnchecked(r, c)) {
+					found = append(found, Coord{Row: r, Col: c})
+				}
+			}
+		}
+		return found
+	}
+	// Storage order matches logical order, so scan each row's slice directly.
+	for r := 0; r < a.height; r++ {
+		for c, v := range a.line(r) {
+			if pred(v) {
+				found = append(found, Coord{Row: r, Col: c})
+			}
+		}
+	}
+	return found
+}
+
+// TrimZeroBorder removes the outer rows and columns of a that contain only the
+// zero value of T, returning the minimal region enclosing all non-zero cells.
+// If every cell is zero, it returns an empty array.
+//
+// The result is a view that shares storage with a, so modifications through it
+// affect the original array. Use Copy to obtain an independent array.
+func TrimZeroBorder[T comparable](a Array2D[T]) Array2D[T] {
+	var zero T
+	top, bottom, left, right := a.height, -1, a.width, -1
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if a.getUnchecked(r, c) == zero {
+				continue
+			}
+			if r < top {
+				top = r
+			}
+			bottom = r
+			if c < left {
+				left = c
+			}
+			if c > right {
+				right = c
+			}
+		}
 	}
-	return Array2D[U]{
-		height:   a.height,
-		width:    a.width,
-		slice:    newSlice,
-		colMajor: a.colMajor,
+	if bottom < 0 {
+		return New[T](0, 0, a.colMajor)
+	}
+	return a.view(top, left, bottom-top+1, right-left+1)
+}
+
+// Aliases reports whether the backing storage of a and b overlaps in memory,
+// meaning that a mutation through one array may be visible through the other.
+// Arrays created by Copy never alias their source, while arrays created by
+// FromSlice alias the slice they were built from.
+//
+// For views, the memory range spanned by the view is compared, so two views of
+// disjoint regions whose rows interleave in memory are reported as aliasing.
+func Aliases[T any](a, b Array2D[T]) bool {
+	aSpan, bSpan := a.span(), b.span()
+	if len(aSpan) == 0 || len(bSpan) == 0 {
+		return false
 	}
+	size := unsafe.Sizeof(aSpan[0])
+	aStart := uintptr(unsafe.Pointer(&aSpan[0]))
+	bStart := uintptr(unsafe.Pointer(&bSpan[0]))
+	aEnd := aStart + uintptr(len(aSpan))*size
+	bEnd := bStart + uintptr(len(bSpan))*size
+	return aStart < bEnd && bStart < aEnd
 }
 
 // Array2D is a 2-dimensional array.
+//
+// The elements are stored in slice, starting at offset. In a row-major array,
+// row r starts at offset+r*stride; in a column-major array, column c starts at
+// offset+c*stride. An array created by this package has a zero offset and a
+// stride equal to its width (row-major) or height (column-major), while a view
+// into a larger array keeps the stride of its parent.
 type Array2D[T any] struct {
 	height, width int
 	slice         []T
+	offset        int
+	stride        int
 	colMajor      bool
 }
 
+// newArray wraps a slice of exactly height*width elements in an Array2D.
+func newArray[T any](height, width int, slice []T, colMajor bool) Array2D[T] {
+	stride := width
+	if colMajor {
+		stride = height
+	}
+	return Array2D[T]{
+		height:   height,
+		width:    width,
+		slice:    slice,
+		stride:   stride,
+		colMajor: colMajor,
+	}
+}
+
+// view returns an array sharing storage with a that covers the height x width
+// region whose top-left corner is at (row, col). The region is not validated.
+func (a Array2D[T]) view(row, col, height, width int) Array2D[T] {
+	v := a
+	v.height, v.width = height, width
+	if height > 0 && width > 0 {
+		v.offset = a.index(row, col)
+	}
+	return v
+}
+
+// index returns the position of the element at (row, col) in the backing slice.
+func (a Array2D[T]) index(row, col int) int {
+	if a.colMajor {
+		return a.offset + col*a.stride + row
+	}
+	return a.offset + row*a.stride + col
+}
+
+// lines returns the number of storage lines (rows for row-major arrays,
+// columns for column-major arrays) and the length of each line. The elements
+// of a single line are always contiguous in memory.
+func (a Array2D[T]) lines() (count, length int) {
+	if a.colMajor {
+		return a.width, a.height
+	}
+	return a.height, a.width
+}
+
+// line returns the i-th storage line as a mutable sub-slice of the backing
+// slice. Its capacity is clipped so appending cannot overwrite other cells.
+func (a Array2D[T]) line(i int) []T {
+	_, length := a.lines()
+	start := a.offset + i*a.stride
+	return a.slice[start : start+length : start+length]
+}
+
+// contiguous returns all elements as a single mutable sub-slice of the backing
+// slice, in storage order. It returns false if the array is a view whose lines
+// are not adjacent in memory.
+func (a Array2D[T]) contiguous() ([]T, bool) {
+	count, length := a.lines()
+	if count > 1 && a.stride != length {
+		return nil, false
+	}
+	n := count * length
+	return a.slice[a.offset : a.offset+n : a.offset+n], true
+}
+
+// span returns the part of the backing slice between the first and the last
+// element of the array, including any cells of a parent array in between.
+func (a Array2D[T]) span() []T {
+	count, length := a.lines()
+	if count == 0 || length == 0 {
+		return nil
+	}
+	return a.slice[a.offset : a.offset+(count-1)*a.stride+length]
+}
+
 // String returns a string representation of this array.
 func (a Array2D[T]) String() string {
-	var t T
-	typeName := reflect.TypeOf(t).Name()
+	// TypeOf a zero T would be nil for interface types, so go through a pointer.
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	typeName := typ.Name()
 	if typeName == "" {
-		typeName = reflect.TypeOf(t).String()
+		typeName = typ.String()
 	}
 
 	var sb strings.Builder
@@ -254,11 +672,17 @@ func (a Array2D[T]) Get(row, col int) (T, bool) {
 	return a.getUnchecked(row, col), true
 }
 
-func (a Array2D[T]) getUnchecked(row, col int) T {
-	if a.colMajor {
-		return a.slice[row+col*a.height]
+// GetOr returns the value at the given position, or fallback if the access is
+// out-of-bounds. It is convenient for neighbor lookups near the edges of a grid.
+func (a Array2D[T]) GetOr(row, col int, fallback T) T {
+	if col < 0 || col >= a.width || row < 0 || row >= a.height {
+		return fallback
 	}
-	return a.slice[col+row*a.width]
+	return a.getUnchecked(row, col)
+}
+
+func (a Array2D[T]) getUnchecked(row, col int) T {
+	return a.slice[a.index(row, col)]
 }
 
 // Set sets a value in the array.
@@ -274,12 +698,70 @@ func (a Array2D[T]) Set(row, col int, value T) error {
 	return nil
 }
 
+// SetV is like Set but returns the array itself so that calls can be chained.
+// It panics on out-of-bounds access, so it is intended for trusted inputs such
+// as test fixtures.
+func (a Array2D[T]) SetV(row, col int, value T) Array2D[T] {
+	if err := a.Set(row, col, value); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// SetGrow sets a value in the array, first growing the array if the position
+// lies beyond its current height or width. New cells are filled with the zero
+// value of T and the memory layout is preserved. Growing allocates new storage,
+// so views and slices obtained from the array before growing no longer alias it.
+//
+// It returns an error if row or col is negative.
+func (a *Array2D[T]) SetGrow(row, col int, value T) error {
+	if row < 0 || col < 0 {
+		return fmt.Errorf("%w: negative index (%d, %d)", ErrOutOfBounds, row, col)
+	}
+	if row >= a.height || col >= a.width {
+		newHeight, newWidth := a.height, a.width
+		if row >= newHeight {
+			newHeight = row + 1
+		}
+		if col >= newWidth {
+			newWidth = col + 1
+		}
+		var zero T
+		*a = a.Resize(newHeight, newWidth, zero)
+	}
+	a.setUnchecked(row, col, value)
+	return nil
+}
+
 func (a Array2D[T]) setUnchecked(row, col int, value T) {
-	if a.colMajor {
-		a.slice[row+col*a.height] = value
-	} else {
-		a.slice[col+row*a.width] = value
+	a.slice[a.index(row, col)] = value
+}
+
+// GetNeg is like Get but interprets negative indices as offsets from the end,
+// so row -1 is the last row and col -1 is the last column.
+// It returns the zero value for T and false if the resolved index is still
+// out-of-bounds.
+func (a Array2D[T]) GetNeg(row, col int) (T, bool) {
+	if row < 0 {
+		row += a.height
+	}
+	if col < 0 {
+		col += a.width
+	}
+	return a.Get(row, col)
+}
+
+// SetNeg is like Set but interprets negative indices as offsets from the end,
+// so row -1 is the last row and col -1 is the last column.
+// It returns an error if the resolved index is still out-of-bounds.
+func (a Array2D[T]) SetNeg(row, col int, value T) error {
+	if row < 0 {
+		row += a.height
 	}
+	if col < 0 {
+		col += a.width
+	}
+	return a.Set(row, col, value)
 }
 
 // Width returns the width of this array. The maximum x value is Width()-1.
@@ -292,102 +774,1034 @@ func (a Array2D[T]) Height() int {
 	return a.height
 }
 
-// Copy returns a shallow copy of this array.
-func (a Array2D[T]) Copy() Array2D[T] {
-	slice := make([]T, len(a.slice))
-	copy(slice, a.slice)
-	return Array2D[T]{
-		height:   a.height,
-		width:    a.width,
-		slice:    slice,
-		colMajor: a.colMajor,
+// Values returns the backing slice of the array directly, without copying.
+//
+// The returned slice aliases the array: modifications to it are visible through
+// Get and all other accessors, and vice versa. Elements are in storage order,
+// which is row by row for row-major arrays and column by column for
+// column-major arrays. It is the fastest way to visit every element when the
+// order does not matter.
+//
+// For views whose rows (or columns) are not adjacent in memory, this function
+// returns a new slice containing a copy of the data in storage order, so
+// modifications to it will not affect the original array.
+func (a Array2D[T]) Values() []T {
+	if data, ok := a.contiguous(); ok {
+		return data
 	}
+	return a.Copy().slice
 }
 
-// Row returns a mutable slice for an entire row. Changing values in this slice
-// will affect the array.
-//
-// For column-major arrays, this function returns a new slice containing a copy
-// of the data, so modifications to it will not affect the original array.
-func (a Array2D[T]) Row(row int) ([]T, bool) {
-	if row < 0 || row >= a.height {
-		return nil, false
+// Flatten returns a new slice with all elements of the array, row by row if
+// rowMajorOrder is true and column by column otherwise, regardless of the
+// array's memory layout. The returned slice never aliases the array.
+func (a Array2D[T]) Flatten(rowMajorOrder bool) []T {
+	result := make([]T, 0, a.height*a.width)
+	if rowMajorOrder != a.colMajor {
+		count, _ := a.lines()
+		for i := 0; i < count; i++ {
+			result = append(result, a.line(i)...)
+		}
+		return result
 	}
-	if a.colMajor {
-		r := make([]T, a.width)
-		for c := 0; c < a.width; c++ {
-			r[c] = a.getUnchecked(row, c)
+	if rowMajorOrder {
+		for r := 0; r < a.height; r++ {
+			for c := 0; c < a.width; c++ {
+				result = append(result, a.getUnchecked(r, c))
+			}
+		}
+		return result
+	}
+	for c := 0; c < a.width; c++ {
+		for r := 0; r < a.height; r++ {
+			result = append(result, a.getUnchecked(r, c))
 		}
-		return r, true
 	}
-	return a.slice[row*a.width : a.width+row*a.width], true
+	return result
 }
 
-// Col returns a slice for an entire column.
+// Layout describes how the array's elements are stored, for handing the raw
+// memory to external routines such as C or BLAS-style code. data is the whole
+// backing slice, which for a view may also hold cells of the parent array.
+// The element at (row, col) is
 //
-// For row-major arrays, this function returns a new slice containing a copy
-// of the data, so modifications to it will not affect the original array.
+//	data[offset + row*stride + col]    for row-major arrays (colMajor == false)
+//	data[offset + col*stride + row]    for column-major arrays (colMajor == true)
 //
-// For column-major arrays, this function returns a mutable slice. Changing
-// values in this slice will affect the array.
-func (a Array2D[T]) Col(col int) ([]T, bool) {
-	if col < 0 || col >= a.width {
-		return nil, false
-	}
-	if a.colMajor {
-		start := col * a.height
-		return a.slice[start : start+a.height], true
-	}
-	c := make([]T, a.height)
-	for r := 0; r < a.height; r++ {
-		c[r] = a.getUnchecked(r, col)
-	}
-	return c, true
+// data aliases the array, so modifications to it affect the array.
+func (a Array2D[T]) Layout() (data []T, offset, stride int, colMajor bool) {
+	return a.slice, a.offset, a.stride, a.colMajor
 }
 
-// Fill will assign all values inside the region to the specified value.
-// The coordinates are inclusive, meaning all values from [row1,col1] including
-// [row1,col1] to [row2,col2] including [row2,col2] are set.
-//
-// The method sorts the arguments, so col2 may be lower than col1 and row2 may be
-// lower than row1.
-func (a Array2D[T]) Fill(row1, col1, row2, col2 int, value T) error {
-	if col1 < 0 || col1 >= a.width {
-		return fmt.Errorf("%w: col1 index %d out of range for width %d", ErrOutOfBounds, col1, a.width)
+// Validate checks that the array's dimensions, stride and offset are
+// consistent with its backing slice, so that every cell can be accessed. It
+// returns an error wrapping ErrShape that describes the first inconsistency
+// found, or nil for a valid array. The zero Array2D is valid.
+func (a Array2D[T]) Validate() error {
+	if a.height < 0 || a.width < 0 {
+		return fmt.Errorf("%w: negative dimensions %dx%d", ErrShape, a.height, a.width)
 	}
-	if row1 < 0 || row1 >= a.height {
-		return fmt.Errorf("%w: row1 index %d out of range for height %d", ErrOutOfBounds, row1, a.height)
+	count, length := a.lines()
+	if count == 0 || length == 0 {
+		return nil
 	}
-	if col2 < 0 || col2 >= a.width {
-		return fmt.Errorf("%w: col2 index %d out of range for width %d", ErrOutOfBounds, col2, a.width)
+	if a.offset < 0 {
+		return fmt.Errorf("%w: negative offset %d", ErrShape, a.offset)
 	}
-	if row2 < 0 || row2 >= a.height {
-		return fmt.Errorf("%w: row2 index %d out of range for height %d", ErrOutOfBounds, row2, a.height)
+	if count > 1 && a.stride < length {
+		return fmt.Errorf("%w: stride %d is smaller than line length %d", ErrShape, a.stride, length)
 	}
-
-	if a.colMajor {
-		// For simplicity, fill cell by cell for column-major.
-		// This can be optimized if needed.
-		for r := row1; r <= row2; r++ {
-			for c := col1; c <= col2; c++ {
-				a.setUnchecked(r, c, value)
-			}
-		}
-		return nil
+	if end := a.offset + (count-1)*a.stride + length; end > len(a.slice) {
+		return fmt.Errorf("%w: %dx%d array with offset %d and stride %d needs %d elements, but backing slice has %d",
+			ErrShape, a.height, a.width, a.offset, a.stride, end, len(a.slice))
 	}
+	return nil
+}
 
+// Copy returns a shallow copy of this array.
+// The copy has the same memory layout but never shares storage with a.
+func (a Array2D[T]) Copy() Array2D[T] {
+	result := newArray(a.height, a.width, make([]T, a.height*a.width), a.colMajor)
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		copy(result.line(i), a.line(i))
+	}
+	return result
+}
+
+// SubArray returns a view of the inclusive region with corners (row1, col1)
+// and (row2, col2). The corners may be given in any order, as with Fill.
+//
+// The view shares storage with a, so writes through it are visible in a and
+// vice versa. Use Copy to detach it from the parent.
+//
+// It returns an error if any of the coordinates are out of bounds.
+func (a Array2D[T]) SubArray(row1, col1, row2, col2 int) (Array2D[T], error) {
+	if err := checkRegion(a.height, a.width, row1, col1, row2, col2); err != nil {
+		return Array2D[T]{}, err
+	}
+	row1, col1, row2, col2 = sortRegion(row1, col1, row2, col2)
+	return a.view(row1, col1, row2-row1+1, col2-col1+1), nil
+}
+
+// Transpose returns a new array with rows and columns swapped, so that the
+// element at (row, col) of a is at (col, row) of the result.
+// The result has the same memory layout as a and never shares storage with it.
+func (a Array2D[T]) Transpose() Array2D[T] {
+	result := New[T](a.width, a.height, a.colMajor)
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			result.setUnchecked(c, r, a.getUnchecked(r, c))
+		}
+	}
+	return result
+}
+
+// TransposeView returns a transposed view of a that shares its storage, so
+// that Get(row, col) on the view reads a.Get(col, row) and writes through the
+// view are visible in a. No elements are copied: the view reinterprets the
+// backing slice with the opposite memory layout, which means a view of a
+// row-major array is column-major and vice versa.
+func (a Array2D[T]) TransposeView() Array2D[T] {
+	v := a
+	v.height, v.width = a.width, a.height
+	v.colMajor = !a.colMajor
+	return v
+}
+
+// ResizeCentered returns a new array of the given dimensions with the contents
+// of a centered in it. Growing pads the new cells with fill and shrinking crops
+// the outer cells, in both cases symmetrically.
+//
+// When the difference between the old and new size along an axis is odd, the
+// top (or left) margin receives the smaller half: an odd extra row is padded at
+// the bottom when growing and cropped from the bottom when shrinking.
+// The result has the same memory layout as a.
+func (a Array2D[T]) ResizeCentered(newHeight, newWidth int, fill T) Array2D[T] {
+	result := NewFilled(newHeight, newWidth, fill, a.colMajor)
+	rowShift := (newHeight - a.height) / 2
+	colShift := (newWidth - a.width) / 2
+	for r := 0; r < newHeight; r++ {
+		srcRow := r - rowShift
+		if srcRow < 0 || srcRow >= a.height {
+			continue
+		}
+		for c := 0; c < newWidth; c++ {
+			srcCol := c - colShift
+			if srcCol < 0 || srcCol >= a.width {
+				continue
+			}
+			result.setUnchecked(r, c, a.getUnchecked(srcRow, srcCol))
+		}
+	}
+	return result
+}
+
+// Recenter returns a new array that is a toroidal shift of a placing the
+// element at (row, col) at (0, 0): the cell at (r, c) of the result is the
+// cell at ((r+row) mod Height(), (c+col) mod Width()) of a. The result has the
+// same memory layout as a.
+//
+// It returns an error if (row, col) is out of bounds.
+func (a Array2D[T]) Recenter(row, col int) (Array2D[T], error) {
+	if col < 0 || col >= a.width {
+		return Array2D[T]{}, fmt.Errorf("%w: col index %d out of range for width %d", ErrOutOfBounds, col, a.width)
+	}
+	if row < 0 || row >= a.height {
+		return Array2D[T]{}, fmt.Errorf("%w: row index %d out of range for height %d", ErrOutOfBounds, row, a.height)
+	}
+	return a.roll(-row, -col), nil
+}
+
+// RollRows returns a new array in which the rows of a are cyclically shifted
+// down by n, like numpy.roll along axis 0: rows pushed past the bottom edge
+// reappear at the top. Negative values of n shift up.
+// The result has the same memory layout as a.
+func (a Array2D[T]) RollRows(n int) Array2D[T] {
+	return a.roll(n, 0)
+}
+
+// RollCols returns a new array in which the columns of a are cyclically
+// shifted right by n, like numpy.roll along axis 1: columns pushed past the
+// right edge reappear on the left. Negative values of n shift left.
+// The result has the same memory layout as a.
+func (a Array2D[T]) RollCols(n int) Array2D[T] {
+	return a.roll(0, n)
+}
+
+// ShiftRows returns a new array in which the rows of a are shifted down by n
+// without wraparound: rows pushed past the bottom edge are dropped and the
+// vacated rows at the top are filled with fill. Negative values of n shift up.
+// The result has the same memory layout as a.
+func (a Array2D[T]) ShiftRows(n int, fill T) Array2D[T] {
+	result := NewFilled(a.height, a.width, fill, a.colMajor)
+	for r := 0; r < a.height; r++ {
+		dr := r + n
+		if dr < 0 || dr >= a.height {
+			continue
+		}
+		for c := 0; c < a.width; c++ {
+			result.setUnchecked(dr, c, a.getUnchecked(r, c))
+		}
+	}
+	return result
+}
+
+// ShiftCols returns a new array in which the columns of a are shifted right by
+// n without wraparound: columns pushed past the right edge are dropped and the
+// vacated columns on the left are filled with fill. Negative values of n shift
+// left. The result has the same memory layout as a.
+func (a Array2D[T]) ShiftCols(n int, fill T) Array2D[T] {
+	result := NewFilled(a.height, a.width, fill, a.colMajor)
+	for c := 0; c < a.width; c++ {
+		dc := c + n
+		if dc < 0 || dc >= a.width {
+			continue
+		}
+		for r := 0; r < a.height; r++ {
+			result.setUnchecked(r, dc, a.getUnchecked(r, c))
+		}
+	}
+	return result
+}
+
+// roll returns a new array with the same memory layout in which the contents
+// of a are cyclically shifted down by rows and right by cols; negative values
+// shift up or left.
+func (a Array2D[T]) roll(rows, cols int) Array2D[T] {
+	result := New[T](a.height, a.width, a.colMajor)
+	if a.height == 0 || a.width == 0 {
+		return result
+	}
+	rows = ((rows % a.height) + a.height) % a.height
+	cols = ((cols % a.width) + a.width) % a.width
+	for r := 0; r < a.height; r++ {
+		dr := (r + rows) % a.height
+		for c := 0; c < a.width; c++ {
+			result.setUnchecked(dr, (c+cols)%a.width, a.getUnchecked(r, c))
+		}
+	}
+	return result
+}
+
+// Resize returns a new array of the given dimensions with the same memory
+// layout. The overlapping top-left region of a is copied, so growing keeps all
+// existing cells at their coordinates and pads the new cells with fill, while
+// shrinking crops the bottom rows and right columns.
+func (a Array2D[T]) Resize(newHeight, newWidth int, fill T) Array2D[T] {
+	result := NewFilled(newHeight, newWidth, fill, a.colMajor)
+	height, width := a.height, a.width
+	if newHeight < height {
+		height = newHeight
+	}
+	if newWidth < width {
+		width = newWidth
+	}
+	for r := 0; r < height; r++ {
+		for c := 0; c < width; c++ {
+			result.setUnchecked(r, c, a.getUnchecked(r, c))
+		}
+	}
+	return result
+}
+
+// PermuteRows returns a new array whose row i is row perm[i] of a.
+// perm must contain each index 0..Height()-1 exactly once, otherwise
+// ErrPermutation is returned. The result has the same memory layout as a.
+func (a Array2D[T]) PermuteRows(perm []int) (Array2D[T], error) {
+	if err := checkPermutation(perm, a.height); err != nil {
+		return Array2D[T]{}, err
+	}
+	result := New[T](a.height, a.width, a.colMajor)
+	if !a.colMajor {
+		for i, p := range perm {
+			copy(result.line(i), a.line(p))
+		}
+		return result, nil
+	}
+	for c := 0; c < a.width; c++ {
+		src, dst := a.line(c), result.line(c)
+		for i, p := range perm {
+			dst[i] = src[p]
+		}
+	}
+	return result, nil
+}
+
+// PermuteCols returns a new array whose column i is column perm[i] of a.
+// perm must contain each index 0..Width()-1 exactly once, otherwise
+// ErrPermutation is returned. The result has the same memory layout as a.
+func (a Array2D[T]) PermuteCols(perm []int) (Array2D[T], error) {
+	if err := checkPermutation(perm, a.width); err != nil {
+		return Array2D[T]{}, err
+	}
+	result := New[T](a.height, a.width, a.colMajor)
+	if a.colMajor {
+		for i, p := range perm {
+			copy(result.line(i), a.line(p))
+		}
+		return result, nil
+	}
+	for r := 0; r < a.height; r++ {
+		src, dst := a.line(r), result.line(r)
+		for i, p := range perm {
+			dst[i] = src[p]
+		}
+	}
+	return result, nil
+}
+
+// InsertRow returns a new array with row inserted before the row at index, so
+// that it becomes row index of the result. index may equal Height() to append
+// a row at the bottom. The result has the same memory layout as a.
+//
+// It returns ErrOutOfBounds if index is out of range and ErrShape if len(row)
+// differs from Width().
+func (a Array2D[T]) InsertRow(index int, row []T) (Array2D[T], error) {
+	if index < 0 || index > a.height {
+		return Array2D[T]{}, fmt.Errorf("%w: row index %d out of range for insertion into height %d", ErrOutOfBounds, index, a.height)
+	}
+	if len(row) != a.width {
+		return Array2D[T]{}, fmt.Errorf("%w: row length %d does not match width %d", ErrShape, len(row), a.width)
+	}
+	result := New[T](a.height+1, a.width, a.colMajor)
+	for r := 0; r < result.height; r++ {
+		for c := 0; c < a.width; c++ {
+			switch {
+			case r < index:
+				result.setUnchecked(r, c, a.getUnchecked(r, c))
+			case r == index:
+				result.setUnchecked(r, c, row[c])
+			default:
+				result.setUnchecked(r, c, a.getUnchecked(r-1, c))
+			}
+		}
+	}
+	return result, nil
+}
+
+// DeleteRow returns a new array without the row at index. The result has the
+// same memory layout as a.
+//
+// It returns an error if index is out of range.
+func (a Array2D[T]) DeleteRow(index int) (Array2D[T], error) {
+	if index < 0 || index >= a.height {
+		return Array2D[T]{}, fmt.Errorf("%w: row index %d out of range for height %d", ErrOutOfBounds, index, a.height)
+	}
+	result := New[T](a.height-1, a.width, a.colMajor)
+	for r := 0; r < result.height; r++ {
+		src := r
+		if r >= index {
+			src++
+		}
+		for c := 0; c < a.width; c++ {
+			result.setUnchecked(r, c, a.getUnchecked(src, c))
+		}
+	}
+	return result, nil
+}
+
+// InsertCol returns a new array with col inserted before the column at index,
+// so that it becomes column index of the result. index may equal Width() to
+// append a column on the right. The result has the same memory layout as a.
+//
+// It returns ErrOutOfBounds if index is out of range and ErrShape if len(col)
+// differs from Height().
+func (a Array2D[T]) InsertCol(index int, col []T) (Array2D[T], error) {
+	if index < 0 || index > a.width {
+		return Array2D[T]{}, fmt.Errorf("%w: col index %d out of range for insertion into width %d", ErrOutOfBounds, index, a.width)
+	}
+	if len(col) != a.height {
+		return Array2D[T]{}, fmt.Errorf("%w: col length %d does not match height %d", ErrShape, len(col), a.height)
+	}
+	result := New[T](a.height, a.width+1, a.colMajor)
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < result.width; c++ {
+			switch {
+			case c < index:
+				result.setUnchecked(r, c, a.getUnchecked(r, c))
+			case c == index:
+				result.setUnchecked(r, c, col[r])
+			default:
+				result.setUnchecked(r, c, a.getUnchecked(r, c-1))
+			}
+		}
+	}
+	return result, nil
+}
+
+// DeleteCol returns a new array without the column at index. The result has
+// the same memory layout as a.
+//
+// It returns an error if index is out of range.
+func (a Array2D[T]) DeleteCol(index int) (Array2D[T], error) {
+	if index < 0 || index >= a.width {
+		return Array2D[T]{}, fmt.Errorf("%w: col index %d out of range for width %d", ErrOutOfBounds, index, a.width)
+	}
+	result := New[T](a.height, a.width-1, a.colMajor)
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < result.width; c++ {
+			src := c
+			if c >= index {
+				src++
+			}
+			result.setUnchecked(r, c, a.getUnchecked(r, src))
+		}
+	}
+	return result, nil
+}
+
+// FlipHorizontal returns a new array mirrored left to right, so that column c
+// of a becomes column Width()-1-c of the result. The result has the same
+// memory layout as a.
+func (a Array2D[T]) FlipHorizontal() Array2D[T] {
+	result := a.Copy()
+	result.FlipHorizontalInPlace()
+	return result
+}
+
+// FlipVertical returns a new array mirrored top to bottom, so that row r of a
+// becomes row Height()-1-r of the result. The result has the same memory
+// layout as a.
+func (a Array2D[T]) FlipVertical() Array2D[T] {
+	result := a.Copy()
+	result.FlipVerticalInPlace()
+	return result
+}
+
+// FlipHorizontalInPlace mirrors the array left to right in place.
+func (a Array2D[T]) FlipHorizontalInPlace() {
+	for i, j := 0, a.width-1; i < j; i, j = i+1, j-1 {
+		_ = a.SwapCols(i, j)
+	}
+}
+
+// FlipVerticalInPlace mirrors the array top to bottom in place.
+func (a Array2D[T]) FlipVerticalInPlace() {
+	for i, j := 0, a.height-1; i < j; i, j = i+1, j-1 {
+		_ = a.SwapRows(i, j)
+	}
+}
+
+// SwapRows exchanges rows i and j in place. Rows of row-major arrays are
+// swapped line by line in memory; column-major arrays swap them cell by cell.
+//
+// It returns an error if either index is out of range.
+func (a Array2D[T]) SwapRows(i, j int) error {
+	if i < 0 || i >= a.height {
+		return fmt.Errorf("%w: row index %d out of range for height %d", ErrOutOfBounds, i, a.height)
+	}
+	if j < 0 || j >= a.height {
+		return fmt.Errorf("%w: row index %d out of range for height %d", ErrOutOfBounds, j, a.height)
+	}
+	if i == j {
+		return nil
+	}
+	if !a.colMajor {
+		swapLines(a.line(i), a.line(j))
+		return nil
+	}
+	for c := 0; c < a.width; c++ {
+		ii, jj := a.index(i, c), a.index(j, c)
+		a.slice[ii], a.slice[jj] = a.slice[jj], a.slice[ii]
+	}
+	return nil
+}
+
+// SwapCols exchanges columns i and j in place. Columns of column-major arrays
+// are swapped line by line in memory; row-major arrays swap them cell by cell.
+//
+// It returns an error if either index is out of range.
+func (a Array2D[T]) SwapCols(i, j int) error {
+	if i < 0 || i >= a.width {
+		return fmt.Errorf("%w: col index %d out of range for width %d", ErrOutOfBounds, i, a.width)
+	}
+	if j < 0 || j >= a.width {
+		return fmt.Errorf("%w: col index %d out of range for width %d", ErrOutOfBounds, j, a.width)
+	}
+	if i == j {
+		return nil
+	}
+	if a.colMajor {
+		swapLines(a.line(i), a.line(j))
+		return nil
+	}
+	for r := 0; r < a.height; r++ {
+		ii, jj := a.index(r, i), a.index(r, j)
+		a.slice[ii], a.slice[jj] = a.slice[jj], a.slice[ii]
+	}
+	return nil
+}
+
+// swapLines exchanges the contents of two equally long, non-overlapping slices.
+func swapLines[T any](x, y []T) {
+	for k := range x {
+		x[k], y[k] = y[k], x[k]
+	}
+}
+
+// FilterRows returns a new array containing, in order, only the rows of a for
+// which keep returns true. The width and memory layout are unchanged.
+//
+// keep receives each row as returned by Row: a slice aliasing the array for
+// row-major arrays and a copy for column-major arrays.
+func (a Array2D[T]) FilterRows(keep func(row []T) bool) Array2D[T] {
+	var kept []int
+	for r := 0; r < a.height; r++ {
+		row, _ := a.Row(r)
+		if keep(row) {
+			kept = append(kept, r)
+		}
+	}
+	result := New[T](len(kept), a.width, a.colMajor)
+	for i, r := range kept {
+		if !a.colMajor {
+			copy(result.line(i), a.line(r))
+			continue
+		}
+		for c := 0; c < a.width; c++ {
+			result.setUnchecked(i, c, a.getUnchecked(r, c))
+		}
+	}
+	return result
+}
+
+// checkPermutation returns an error unless perm holds each of 0..n-1 once.
+func checkPermutation(perm []int, n int) error {
+	if len(perm) != n {
+		return fmt.Errorf("%w: length %d does not match dimension %d", ErrPermutation, len(perm), n)
+	}
+	seen := make([]bool, n)
+	for i, p := range perm {
+		if p < 0 || p >= n {
+			return fmt.Errorf("%w: index %d at position %d out of range for dimension %d", ErrPermutation, p, i, n)
+		}
+		if seen[p] {
+			return fmt.Errorf("%w: duplicate index %d at position %d", ErrPermutation, p, i)
+		}
+		seen[p] = true
+	}
+	return nil
+}
+
+// CopyFrom copies all of src into the array, which must have the same
+// dimensions (ErrShape otherwise). It is the in-place counterpart of Copy and
+// allocates nothing, unless src shares storage with the array: then src is
+// copied first, so the result is as if src had been read in full before any
+// cell was written, as in a.CopyFrom(a.TransposeView()).
+//
+// When both arrays share the same memory layout, the data is copied with bulk
+// copies; otherwise it is copied cell by cell in logical order.
+func (a Array2D[T]) CopyFrom(src Array2D[T]) error {
+	if a.height != src.height || a.width != src.width {
+		return fmt.Errorf("%w: source %dx%d does not match destination %dx%d", ErrShape, src.height, src.width, a.height, a.width)
+	}
+	if Aliases(a, src) {
+		src = src.Copy()
+	}
+	if a.colMajor == src.colMajor {
+		dstData, dstOK := a.contiguous()
+		srcData, srcOK := src.contiguous()
+		if dstOK && srcOK {
+			copy(dstData, srcData)
+			return nil
+		}
+		count, _ := a.lines()
+		for i := 0; i < count; i++ {
+			copy(a.line(i), src.line(i))
+		}
+		return nil
+	}
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			a.setUnchecked(r, c, src.getUnchecked(r, c))
+		}
+	}
+	return nil
+}
+
+// Row returns a mutable slice for an entire row. Changing values in this slice
+// will affect the array.
+//
+// For column-major arrays, this function returns a new slice containing a copy
+// of the data, so modifications to it will not affect the original array.
+func (a Array2D[T]) Row(row int) ([]T, bool) {
+	if row < 0 || row >= a.height {
+		return nil, false
+	}
+	if a.colMajor {
+		r := make([]T, a.width)
+		for c := 0; c < a.width; c++ {
+			r[c] = a.getUnchecked(row, c)
+		}
+		return r, true
+	}
+	return a.line(row), true
+}
+
+// Col returns a slice for an entire column.
+//
+// For row-major arrays, this function returns a new slice containing a copy
+// of the data, so modifications to it will not affect the original array.
+//
+// For column-major arrays, this function returns a mutable slice. Changing
+// values in this slice will affect the array.
+func (a Array2D[T]) Col(col int) ([]T, bool) {
+	if col < 0 || col >= a.width {
+		return nil, false
+	}
+	if a.colMajor {
+		return a.line(col), true
+	}
+	c := make([]T, a.height)
+	for r := 0; r < a.height; r++ {
+		c[r] = a.getUnchecked(r, col)
+	}
+	return c, true
+}
+
+// Diagonal returns a copy of the main diagonal of the array: the cells (i, i)
+// for i < min(Height(), Width()), from top-left to bottom-right.
+func (a Array2D[T]) Diagonal() []T {
+	diag := make([]T, a.diagonalLen())
+	for i := range diag {
+		diag[i] = a.getUnchecked(i, i)
+	}
+	return diag
+}
+
+// AntiDiagonal returns a copy of the anti-diagonal of the array: the cells
+// (i, Width()-1-i) for i < min(Height(), Width()), from top-right to
+// bottom-left.
+func (a Array2D[T]) AntiDiagonal() []T {
+	diag := make([]T, a.diagonalLen())
+	for i := range diag {
+		diag[i] = a.getUnchecked(i, a.width-1-i)
+	}
+	return diag
+}
+
+// SetDiagonal assigns values to the main diagonal, in the order returned by
+// Diagonal. It returns ErrDestLength if len(values) differs from the length of
+// the diagonal.
+func (a Array2D[T]) SetDiagonal(values []T) error {
+	if n := a.diagonalLen(); len(values) != n {
+		return fmt.Errorf("%w: values slice has length %d, but diagonal has %d cells", ErrDestLength, len(values), n)
+	}
+	for i, v := range values {
+		a.setUnchecked(i, i, v)
+	}
+	return nil
+}
+
+// SetAntiDiagonal assigns values to the anti-diagonal, in the order returned by
+// AntiDiagonal. It returns ErrDestLength if len(values) differs from the
+// length of the anti-diagonal.
+func (a Array2D[T]) SetAntiDiagonal(values []T) error {
+	if n := a.diagonalLen(); len(values) != n {
+		return fmt.Errorf("%w: values slice has length %d, but anti-diagonal has %d cells", ErrDestLength, len(values), n)
+	}
+	for i, v := range values {
+		a.setUnchecked(i, a.width-1-i, v)
+	}
+	return nil
+}
+
+// diagonalLen returns the number of cells on the main diagonal.
+func (a Array2D[T]) diagonalLen() int {
+	if a.height < a.width {
+		return a.height
+	}
+	return a.width
+}
+
+// DiagonalOffset returns a copy of the k-th diagonal of the array: the cells
+// (i, i+k) that lie inside the array, from top-left to bottom-right. k = 0 is
+// the main diagonal, k > 0 selects diagonals above it and k < 0 below it.
+//
+// It returns an error if k is outside the range -(Height()-1)..Width()-1.
+func (a Array2D[T]) DiagonalOffset(k int) ([]T, error) {
+	if k <= -a.height || k >= a.width {
+		return nil, fmt.Errorf("%w: diagonal offset %d out of range for %dx%d array", ErrOutOfBounds, k, a.height, a.width)
+	}
+	row, col := 0, k
+	if k < 0 {
+		row, col = -k, 0
+	}
+	var diag []T
+	for ; row < a.height && col < a.width; row, col = row+1, col+1 {
+		diag = append(diag, a.getUnchecked(row, col))
+	}
+	return diag, nil
+}
+
+// RowE is like Row but returns ErrOutOfBounds instead of false when the row
+// index is out of range.
+func (a Array2D[T]) RowE(row int) ([]T, error) {
+	r, ok := a.Row(row)
+	if !ok {
+		return nil, fmt.Errorf("%w: row index %d out of range for height %d", ErrOutOfBounds, row, a.height)
+	}
+	return r, nil
+}
+
+// ColE is like Col but returns ErrOutOfBounds instead of false when the column
+// index is out of range.
+func (a Array2D[T]) ColE(col int) ([]T, error) {
+	c, ok := a.Col(col)
+	if !ok {
+		return nil, fmt.Errorf("%w: col index %d out of range for width %d", ErrOutOfBounds, col, a.width)
+	}
+	return c, nil
+}
+
+// Fill will assign all values inside the region to the specified value.
+// The coordinates are inclusive, meaning all values from [row1,col1] including
+// [row1,col1] to [row2,col2] including [row2,col2] are set.
+//
+// The method sorts the arguments, so col2 may be lower than col1 and row2 may be
+// lower than row1.
+func (a Array2D[T]) Fill(row1, col1, row2, col2 int, value T) error {
+	if err := checkRegion(a.height, a.width, row1, col1, row2, col2); err != nil {
+		return err
+	}
+
+	row1, col1, row2, col2 = sortRegion(row1, col1, row2, col2)
+
+	// Fill the region's part of the first storage line, then copy it into the
+	// same part of every following line.
+	first, last, lo, hi := row1, row2, col1, col2
+	if a.colMajor {
+		first, last, lo, hi = col1, col2, row1, row2
+	}
+	firstLine := a.line(first)[lo : hi+1]
+	fill(firstLine, value)
+	for i := first + 1; i <= last; i++ {
+		copy(a.line(i)[lo:hi+1], firstLine)
+	}
+	return nil
+}
+
+// FillV is like Fill but returns the array itself so that calls can be
+// chained. It panics if any of the coordinates are out of bounds, so it is
+// intended for trusted inputs such as test fixtures.
+func (a Array2D[T]) FillV(row1, col1, row2, col2 int, value T) Array2D[T] {
+	if err := a.Fill(row1, col1, row2, col2, value); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// SetRegion writes values into the region, where values holds the region's
+// cells in logical row-major order. The coordinates are inclusive and may be
+// given in any order, as with Fill.
+//
+// It returns an error if any of the coordinates are out of bounds, or
+// ErrDestLength if len(values) differs from the number of cells in the region.
+func (a Array2D[T]) SetRegion(row1, col1, row2, col2 int, values []T) error {
+	if err := checkRegion(a.height, a.width, row1, col1, row2, col2); err != nil {
+		return err
+	}
+	row1, col1, row2, col2 = sortRegion(row1, col1, row2, col2)
+	width := col2 - col1 + 1
+	if n := (row2 - row1 + 1) * width; len(values) != n {
+		return fmt.Errorf("%w: values slice has length %d, but region has %d cells", ErrDestLength, len(values), n)
+	}
+	for r := row1; r <= row2; r++ {
+		src := values[(r-row1)*width : (r-row1+1)*width]
+		if !a.colMajor {
+			copy(a.line(r)[col1:col2+1], src)
+			continue
+		}
+		for i, v := range src {
+			a.setUnchecked(r, col1+i, v)
+		}
+	}
+	return nil
+}
+
+// FillPattern fills the whole array by tiling pattern across it, so that cell
+// (r, c) receives the pattern's cell (r mod pattern.Height(), c mod
+// pattern.Width()). A pattern larger than the array contributes only its
+// top-left region. An empty pattern leaves the array unchanged.
+func (a Array2D[T]) FillPattern(pattern Array2D[T]) {
+	if pattern.height == 0 || pattern.width == 0 {
+		return
+	}
+	for r := 0; r < a.height; r++ {
+		pr := r % pattern.height
+		for c := 0; c < a.width; c++ {
+			a.setUnchecked(r, c, pattern.getUnchecked(pr, c%pattern.width))
+		}
+	}
+}
+
+// FillWhere assigns value to every cell for which pred returns true and
+// returns the number of cells assigned. Cells are visited in logical row-major
+// order and pred receives each cell's position and current value.
+func (a Array2D[T]) FillWhere(value T, pred func(row, col int, current T) bool) int {
+	n := 0
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if pred(r, c, a.getUnchecked(r, c)) {
+				a.setUnchecked(r, c, value)
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// ApplyMask assigns value to every cell of the array whose corresponding cell
+// in mask is true. The mask must have the same dimensions as the array
+// (ErrShape otherwise). Cells are matched by logical position, so the two
+// arrays may use different memory layouts.
+func (a Array2D[T]) ApplyMask(mask Array2D[bool], value T) error {
+	if a.height != mask.height || a.width != mask.width {
+		return fmt.Errorf("%w: mask %dx%d does not match array %dx%d", ErrShape, mask.height, mask.width, a.height, a.width)
+	}
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if mask.getUnchecked(r, c) {
+				a.setUnchecked(r, c, value)
+			}
+		}
+	}
+	return nil
+}
+
+// SetAll assigns value to every cell of the array without reallocating.
+func (a Array2D[T]) SetAll(value T) {
+	if data, ok := a.contiguous(); ok {
+		fill(data, value)
+		return
+	}
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		fill(a.line(i), value)
+	}
+}
+
+// MapInPlace replaces every cell of the array with f applied to its current
+// value. Unlike Map it does not allocate a second array, which makes it the
+// better choice for large arrays when the element type does not change.
+// Cells are visited in storage order.
+func (a Array2D[T]) MapInPlace(f func(T) T) {
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		line := a.line(i)
+		for j, v := range line {
+			line[j] = f(v)
+		}
+	}
+}
+
+// ApplyScalar replaces every cell of the array in place with op(cell, scalar).
+// It generalizes scalar arithmetic to any binary operation, such as adding a
+// constant or clamping against a threshold.
+func (a Array2D[T]) ApplyScalar(op func(cell, scalar T) T, scalar T) {
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		line := a.line(i)
+		for j, v := range line {
+			line[j] = op(v, scalar)
+		}
+	}
+}
+
+// Paste overwrites the region of a whose top-left corner is (atRow, atCol) with
+// the whole of src. It returns an error, and leaves a unchanged, if src does not
+// fit entirely inside a at that position.
+//
+// If src shares storage with the destination region, such as another view of
+// the same array, src is copied first, so overlapping regions paste correctly.
+//
+// When both arrays share the same memory layout, the data is copied a row (or
+// column) at a time.
+func (a Array2D[T]) Paste(src Array2D[T], atRow, atCol int) error {
+	if atRow < 0 || atCol < 0 || atRow+src.height > a.height || atCol+src.width > a.width {
+		return fmt.Errorf("%w: %dx%d array at (%d, %d) does not fit in %dx%d array", ErrOutOfBounds, src.height, src.width, atRow, atCol, a.height, a.width)
+	}
+	dst := a.view(atRow, atCol, src.height, src.width)
+	if Aliases(dst, src) {
+		src = src.Copy()
+	}
+	if src.colMajor == a.colMajor {
+		count, _ := src.lines()
+		for i := 0; i < count; i++ {
+			copy(dst.line(i), src.line(i))
+		}
+		return nil
+	}
+	for r := 0; r < src.height; r++ {
+		for c := 0; c < src.width; c++ {
+			a.setUnchecked(atRow+r, atCol+c, src.getUnchecked(r, c))
+		}
+	}
+	return nil
+}
+
+// ForEach calls f for every element of the array in storage order: row by row
+// for row-major arrays and column by column for column-major arrays. This is
+// the cache-friendly order, so callers need not know the layout.
+func (a Array2D[T]) ForEach(f func(v T)) {
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		for _, v := range a.line(i) {
+			f(v)
+		}
+	}
+}
+
+// ForEachIndexed is like ForEach, but f also receives the row and column of
+// each element.
+func (a Array2D[T]) ForEachIndexed(f func(row, col int, v T)) {
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		for j, v := range a.line(i) {
+			if a.colMajor {
+				f(j, i, v)
+			} else {
+				f(i, j, v)
+			}
+		}
+	}
+}
+
+// Any reports whether pred returns true for at least one element. It stops at
+// the first match, scanning in storage order.
+func (a Array2D[T]) Any(pred func(v T) bool) bool {
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		for _, v := range a.line(i) {
+			if pred(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// All reports whether pred returns true for every element. It stops at the
+// first element that does not match, scanning in storage order. All returns
+// true for an empty array.
+func (a Array2D[T]) All(pred func(v T) bool) bool {
+	return !a.Any(func(v T) bool { return !pred(v) })
+}
+
+// Count returns the number of elements for which pred returns true.
+func (a Array2D[T]) Count(pred func(v T) bool) int {
+	n := 0
+	a.ForEach(func(v T) {
+		if pred(v) {
+			n++
+		}
+	})
+	return n
+}
+
+// ForEachInRegion calls fn for every cell inside the region, in logical
+// row-major order. The coordinates are inclusive and may be given in any order,
+// as with Fill.
+//
+// It returns an error if any of the coordinates are out of bounds.
+func (a Array2D[T]) ForEachInRegion(row1, col1, row2, col2 int, fn func(row, col int, v T)) error {
+	if err := checkRegion(a.height, a.width, row1, col1, row2, col2); err != nil {
+		return err
+	}
+	row1, col1, row2, col2 = sortRegion(row1, col1, row2, col2)
+	for r := row1; r <= row2; r++ {
+		for c := col1; c <= col2; c++ {
+			fn(r, c, a.getUnchecked(r, c))
+		}
+	}
+	return nil
+}
+
+// ForEachNeighbor calls fn for every offset (dr, dc) within the given
+// Chebyshev radius of (row, col), including the center offset (0, 0), in
+// logical row-major order. A radius of 1 therefore yields the 9 cells of a
+// 3x3 kernel.
+//
+// For offsets that fall outside the array, fn receives the zero value of T and
+// inBounds set to false.
+func (a Array2D[T]) ForEachNeighbor(row, col int, radius int, fn func(dr, dc int, v T, inBounds bool)) {
+	for dr := -radius; dr <= radius; dr++ {
+		for dc := -radius; dc <= radius; dc++ {
+			v, ok := a.Get(row+dr, col+dc)
+			fn(dr, dc, v, ok)
+		}
+	}
+}
+
+// checkRegion returns an error if any corner of the region is out of bounds for
+// an array of the given dimensions.
+func checkRegion(height, width, row1, col1, row2, col2 int) error {
+	if col1 < 0 || col1 >= width {
+		return fmt.Errorf("%w: col1 index %d out of range for width %d", ErrOutOfBounds, col1, width)
+	}
+	if row1 < 0 || row1 >= height {
+		return fmt.Errorf("%w: row1 index %d out of range for height %d", ErrOutOfBounds, row1, height)
+	}
+	if col2 < 0 || col2 >= width {
+		return fmt.Errorf("%w: col2 index %d out of range for width %d", ErrOutOfBounds, col2, width)
+	}
+	if row2 < 0 || row2 >= height {
+		return fmt.Errorf("%w: row2 index %d out of range for height %d", ErrOutOfBounds, row2, height)
+	}
+	return nil
+}
+
+// sortRegion orders the corners of a region so that row1 <= row2 and
+// col1 <= col2.
+func sortRegion(row1, col1, row2, col2 int) (int, int, int, int) {
 	if col2 < col1 {
 		col1, col2 = col2, col1
 	}
 	if row2 < row1 {
 		row1, row2 = row2, row1
 	}
-	firstRow := a.slice[col1+row1*a.width : 1+col2+row1*a.width]
-	fill(firstRow, value)
-	for row := row1 + 1; row <= row2; row++ {
-		copy(a.slice[col1+row*a.width:1+col2+row*a.width], firstRow)
-	}
-	return nil
+	return row1, col1, row2, col2
 }
 
 func fill[E any](slice []E, value E) {
@@ -409,16 +1823,30 @@ func (a *Array2D[T]) Rows() *Rows[T] {
 	}
 }
 
+// RowsReverse returns an iterator over the rows of the array that visits them
+// from the last row to the first.
+func (a *Array2D[T]) RowsReverse() *Rows[T] {
+	return &Rows[T]{
+		arr:     a,
+		row:     -1,
+		reverse: true,
+	}
+}
+
 // Rows is an iterator over the rows of an Array2D.
 type Rows[T any] struct {
-	arr *Array2D[T]
-	row int
-	err error
+	arr     *Array2D[T]
+	row     int
+	reverse bool
+	err     error
 }
 
 // Next advances the iterator to the next row.
 // It returns false when the iteration is complete.
 func (r *Rows[T]) Next() bool {
+	if r.reverse {
+		return nextReverse(&r.row, r.arr.height)
+	}
 	if r.row+1 >= r.arr.height {
 		return false
 	}
@@ -461,6 +1889,18 @@ func (r *Rows[T]) Scan(dest *[]T) error {
 	return nil
 }
 
+// Row returns the current row without copying it into a destination.
+//
+// For row-major arrays, this is a zero-copy, zero-allocation operation: the
+// returned slice aliases the array, so changing values in it will affect the
+// array. For column-major arrays, it returns a new slice containing a copy of
+// the data, so modifications to it will not affect the original array.
+//
+// It returns false if Next has not been called yet.
+func (r *Rows[T]) Row() ([]T, bool) {
+	return r.arr.Row(r.row)
+}
+
 // Err returns the error, if any, that was encountered during iteration.
 func (r *Rows[T]) Err() error {
 	return r.err
@@ -474,16 +1914,30 @@ func (a *Array2D[T]) Cols() *Cols[T] {
 	}
 }
 
+// ColsReverse returns an iterator over the columns of the array that visits
+// them from the last column to the first.
+func (a *Array2D[T]) ColsReverse() *Cols[T] {
+	return &Cols[T]{
+		arr:     a,
+		col:     -1,
+		reverse: true,
+	}
+}
+
 // Cols is an iterator over the columns of an Array2D.
 type Cols[T any] struct {
-	arr *Array2D[T]
-	col int
-	err error
+	arr     *Array2D[T]
+	col     int
+	reverse bool
+	err     error
 }
 
 // Next advances the iterator to the next column.
 // It returns false when the iteration is complete.
 func (c *Cols[T]) Next() bool {
+	if c.reverse {
+		return nextReverse(&c.col, c.arr.width)
+	}
 	if c.col+1 >= c.arr.width {
 		return false
 	}
@@ -530,3 +1984,17 @@ func (c *Cols[T]) Scan(dest *[]T) error {
 func (c *Cols[T]) Err() error {
 	return c.err
 }
+
+// nextReverse advances a reverse iterator position. The position starts at -1
+// (not started), jumps to n-1 on the first call and stops after reaching 0.
+func nextReverse(pos *int, n int) bool {
+	switch {
+	case *pos == -1 && n > 0:
+		*pos = n - 1
+		return true
+	case *pos > 0:
+		*pos--
+		return true
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -37,6 +38,24 @@ func TestArray2D_stringValues(t *testing.T) {
 	}
 }
 
+// TestArray2D_stringInterface checks that String does not panic for interface
+// element types, whose zero value has no dynamic type to name.
+func TestArray2D_stringInterface(t *testing.T) {
+	arr, _ := FromSlice(1, 3, []any{1, "a", nil})
+	want := "Array2d[interface {}] 1x3 [[1 a <nil>]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if got, want := New[any](0, 2).String(), "Array2d[interface {}] 0x2 []"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	errs := New[error](1, 2)
+	_ = errs.Set(0, 1, ErrShape)
+	if got, want := errs.String(), "Array2d[error] 1x2 [[<nil> array2d: invalid shape for creation]]"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
 func TestArray2D_stringSummarized(t *testing.T) {
 	t.Run("summarize rows", func(t *testing.T) {
 		arr := New[int](12, 3)
@@ -473,3 +492,1549 @@ func TestMap(t *testing.T) {
 		}
 	})
 }
+
+func TestConcat(t *testing.T) {
+	a, _ := FromSlice(1, 2, []int{1, 2})
+	b, _ := FromSlice(2, 2, []int{3, 4, 5, 6})
+
+	t.Run("axis 0", func(t *testing.T) {
+		got, err := Concat(0, a, b)
+		if err != nil {
+			t.Fatalf("Concat() returned an unexpected error: %v", err)
+		}
+		want := "Array2d[int] 3x2 [[1 2] [3 4] [5 6]]"
+		if got.String() != want {
+			t.Errorf("want %q, got %q", want, got.String())
+		}
+	})
+
+	t.Run("axis 1", func(t *testing.T) {
+		c, _ := FromSlice(2, 1, []int{7, 8}, true)
+		got, err := Concat(1, b, c)
+		if err != nil {
+			t.Fatalf("Concat() returned an unexpected error: %v", err)
+		}
+		want := "Array2d[int] 2x3 [[3 4 7] [5 6 8]]"
+		if got.String() != want {
+			t.Errorf("want %q, got %q", want, got.String())
+		}
+	})
+
+	t.Run("shape mismatch", func(t *testing.T) {
+		_, err := Concat(1, a, b)
+		if !errors.Is(err, ErrShape) {
+			t.Errorf("want error to be ErrShape, but it was not. got: %v", err)
+		}
+	})
+
+	t.Run("invalid axis", func(t *testing.T) {
+		_, err := Concat(2, a, b)
+		if !errors.Is(err, ErrInvalidAxis) {
+			t.Errorf("want error to be ErrInvalidAxis, but it was not. got: %v", err)
+		}
+	})
+}
+
+func TestArray2D_RowE_ColE(t *testing.T) {
+	arr, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+
+	row, err := arr.RowE(1)
+	if err != nil {
+		t.Fatalf("RowE(1) returned an unexpected error: %v", err)
+	}
+	if want := []int{4, 5, 6}; !reflect.DeepEqual(row, want) {
+		t.Errorf("RowE(1): want %v, got %v", want, row)
+	}
+	col, err := arr.ColE(2)
+	if err != nil {
+		t.Fatalf("ColE(2) returned an unexpected error: %v", err)
+	}
+	if want := []int{3, 6}; !reflect.DeepEqual(col, want) {
+		t.Errorf("ColE(2): want %v, got %v", want, col)
+	}
+
+	for _, idx := range []int{-1, 2} {
+		if _, err := arr.RowE(idx); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("RowE(%d): want ErrOutOfBounds, got %v", idx, err)
+		}
+	}
+	for _, idx := range []int{-1, 3} {
+		if _, err := arr.ColE(idx); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("ColE(%d): want ErrOutOfBounds, got %v", idx, err)
+		}
+	}
+}
+
+func TestArray2D_Values(t *testing.T) {
+	t.Run("row-major", func(t *testing.T) {
+		arr, _ := FromSlice(2, 2, []int{1, 2, 3, 4})
+		values := arr.Values()
+		if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(values, want) {
+			t.Errorf("Values(): want %v, got %v", want, values)
+		}
+		values[1] = 42
+		if got, _ := arr.Get(0, 1); got != 42 {
+			t.Errorf("modification through Values() not visible, got %d, want 42", got)
+		}
+	})
+
+	t.Run("column-major", func(t *testing.T) {
+		arr, _ := FromSlice(2, 2, []int{1, 2, 3, 4}, true)
+		values := arr.Values()
+		values[1] = 42
+		if got, _ := arr.Get(1, 0); got != 42 {
+			t.Errorf("modification through Values() not visible, got %d, want 42", got)
+		}
+	})
+}
+
+func TestCountRowsCols(t *testing.T) {
+	arr, _ := FromJagged(3, 3, [][]int{
+		{1, 0, 1},
+		{1, 1, 1},
+		{0, 0, 1},
+	})
+
+	if got, want := CountRows(arr, 1), []int{2, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountRows(): want %v, got %v", want, got)
+	}
+	if got, want := CountCols(arr, 1), []int{2, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountCols(): want %v, got %v", want, got)
+	}
+}
+
+func TestArray2D_SetAll(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr := New[int](3, 4, colMajor)
+		arr.SetAll(7)
+		for r := 0; r < arr.Height(); r++ {
+			for c := 0; c < arr.Width(); c++ {
+				if got, _ := arr.Get(r, c); got != 7 {
+					t.Errorf("colMajor=%v, r=%d, c=%d: want 7, got %d", colMajor, r, c, got)
+				}
+			}
+		}
+	}
+}
+
+func TestArray2D_GetNegSetNeg(t *testing.T) {
+	arr, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+
+	if got, ok := arr.GetNeg(-1, -1); !ok || got != 6 {
+		t.Errorf("GetNeg(-1, -1): want (6, true), got (%d, %v)", got, ok)
+	}
+	if got, ok := arr.GetNeg(-2, 1); !ok || got != 2 {
+		t.Errorf("GetNeg(-2, 1): want (2, true), got (%d, %v)", got, ok)
+	}
+	if _, ok := arr.GetNeg(-3, 0); ok {
+		t.Error("GetNeg(-3, 0): want ok=false for index beyond -height")
+	}
+
+	if err := arr.SetNeg(-1, -3, 42); err != nil {
+		t.Fatalf("SetNeg(-1, -3) returned an unexpected error: %v", err)
+	}
+	if got, _ := arr.Get(1, 0); got != 42 {
+		t.Errorf("SetNeg(-1, -3) did not set (1, 0), got %d", got)
+	}
+	if err := arr.SetNeg(0, -4, 0); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("SetNeg(0, -4): want ErrOutOfBounds, got %v", err)
+	}
+}
+
+func TestArray2D_rowsColsReverse(t *testing.T) {
+	arr, _ := FromSlice(3, 2, []int{0, 1, 2, 3, 4, 5})
+
+	rows := arr.RowsReverse()
+	if rows.Index() != -1 {
+		t.Errorf("initial rows.Index() want -1, got %d", rows.Index())
+	}
+	var rowOrder []int
+	row := make([]int, arr.Width())
+	for rows.Next() {
+		if err := rows.Scan(&row); err != nil {
+			t.Fatalf("error scanning row: %v", err)
+		}
+		if want := []int{rows.Index() * 2, rows.Index()*2 + 1}; !reflect.DeepEqual(row, want) {
+			t.Errorf("row %d: want %v, got %v", rows.Index(), want, row)
+		}
+		rowOrder = append(rowOrder, rows.Index())
+	}
+	if want := []int{2, 1, 0}; !reflect.DeepEqual(rowOrder, want) {
+		t.Errorf("RowsReverse order: want %v, got %v", want, rowOrder)
+	}
+	if rows.Next() {
+		t.Error("Next() returned true after iteration completed")
+	}
+
+	cols := arr.ColsReverse()
+	var colOrder []int
+	for cols.Next() {
+		colOrder = append(colOrder, cols.Index())
+	}
+	if want := []int{1, 0}; !reflect.DeepEqual(colOrder, want) {
+		t.Errorf("ColsReverse order: want %v, got %v", want, colOrder)
+	}
+
+	empty := New[int](0, 0)
+	if empty.RowsReverse().Next() {
+		t.Error("RowsReverse().Next() returned true for an empty array")
+	}
+}
+
+func TestAliases(t *testing.T) {
+	backing := []int{1, 2, 3, 4, 5, 6}
+	arr, _ := FromSlice(2, 3, backing)
+
+	if Aliases(arr, arr.Copy()) {
+		t.Error("Aliases() reported a Copy as aliasing its source")
+	}
+	if !Aliases(arr, arr) {
+		t.Error("Aliases() did not report an array as aliasing itself")
+	}
+
+	reshaped, _ := FromSlice(3, 2, arr.Values())
+	if !Aliases(arr, reshaped) {
+		t.Error("Aliases() did not report a reshaped array as aliasing its source")
+	}
+
+	tail, _ := FromSlice(1, 2, backing[4:])
+	head, _ := FromSlice(1, 2, backing[:2])
+	if !Aliases(arr, tail) {
+		t.Error("Aliases() did not report a partially overlapping array")
+	}
+	if Aliases(head, tail) {
+		t.Error("Aliases() reported disjoint regions of the same slice as aliasing")
+	}
+	if Aliases(arr, New[int](0, 0)) {
+		t.Error("Aliases() reported an empty array as aliasing")
+	}
+}
+
+func TestTryMapAll(t *testing.T) {
+	errNegative := errors.New("negative value")
+	arr, _ := FromSlice(2, 2, []int{1, -2, 3, -4})
+
+	got, errs := TryMapAll(arr, func(v int) (string, error) {
+		if v < 0 {
+			return "bad", errNegative
+		}
+		return fmt.Sprint(v * 10), nil
+	})
+
+	want := "Array2d[string] 2x2 [[10 ] [30 ]]"
+	if got.String() != want {
+		t.Errorf("want %q, got %q", want, got.String())
+	}
+	if len(errs) != 2 {
+		t.Fatalf("want 2 errors, got %d: %v", len(errs), errs)
+	}
+	for i, wantMsg := range []string{"cell (0, 1)", "cell (1, 1)"} {
+		if !errors.Is(errs[i], errNegative) {
+			t.Errorf("errs[%d]: want wrapped errNegative, got %v", i, errs[i])
+		}
+		if !strings.Contains(errs[i].Error(), wantMsg) {
+			t.Errorf("errs[%d]: want message containing %q, got %q", i, wantMsg, errs[i])
+		}
+	}
+
+	if _, errs := TryMapAll(arr, func(v int) (int, error) { return v, nil }); errs != nil {
+		t.Errorf("want nil errors on success, got %v", errs)
+	}
+}
+
+func TestArray2D_GetOr(t *testing.T) {
+	arr, _ := FromSlice(2, 2, []int{1, 2, 3, 4})
+
+	if got := arr.GetOr(1, 1, -1); got != 4 {
+		t.Errorf("GetOr(1, 1): want 4, got %d", got)
+	}
+	for _, pos := range [][2]int{{-1, 0}, {0, -1}, {2, 0}, {0, 2}} {
+		if got := arr.GetOr(pos[0], pos[1], -1); got != -1 {
+			t.Errorf("GetOr(%d, %d): want fallback -1, got %d", pos[0], pos[1], got)
+		}
+	}
+}
+
+func TestCheckerboard(t *testing.T) {
+	arr := Checkerboard(3, 3, "x", "o")
+	want := "Array2d[string] 3x3 [[x o x] [o x o] [x o x]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestArray2D_ForEachInRegion(t *testing.T) {
+	arr := New[int](4, 5)
+	for i := 0; i < arr.Height(); i++ {
+		for j := 0; j < arr.Width(); j++ {
+			_ = arr.Set(i, j, i*10+j)
+		}
+	}
+
+	sum := 0
+	visited := map[[2]int]bool{}
+	err := arr.ForEachInRegion(2, 3, 1, 1, func(row, col int, v int) {
+		sum += v
+		visited[[2]int{row, col}] = true
+	})
+	if err != nil {
+		t.Fatalf("ForEachInRegion() returned an unexpected error: %v", err)
+	}
+	// Rows 1-2, cols 1-3: (11+12+13) + (21+22+23)
+	if sum != 102 {
+		t.Errorf("want sum 102, got %d", sum)
+	}
+	if len(visited) != 6 {
+		t.Errorf("want 6 visited cells, got %d", len(visited))
+	}
+	if visited[[2]int{0, 0}] || visited[[2]int{3, 4}] {
+		t.Error("ForEachInRegion visited cells outside the region")
+	}
+
+	if err := arr.ForEachInRegion(0, 0, 4, 0, func(int, int, int) {}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("want ErrOutOfBounds, got %v", err)
+	}
+}
+
+func TestArray2D_ApplyScalar(t *testing.T) {
+	t.Run("add", func(t *testing.T) {
+		arr, _ := FromSlice(2, 2, []int{1, 2, 3, 4})
+		arr.ApplyScalar(func(cell, scalar int) int { return cell + scalar }, 10)
+		want := "Array2d[int] 2x2 [[11 12] [13 14]]"
+		if got := arr.String(); got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("clamp with max", func(t *testing.T) {
+		arr, _ := FromSlice(2, 2, []int{1, 5, 3, 7}, true)
+		arr.ApplyScalar(func(cell, scalar int) int {
+			if cell > scalar {
+				return scalar
+			}
+			return cell
+		}, 4)
+		want := "Array2d[int] 2x2 [[1 3] [4 4]]"
+		if got := arr.String(); got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	})
+}
+
+func TestArray2D_viewStride(t *testing.T) {
+	newParent := func(colMajor bool) Array2D[int] {
+		arr := New[int](4, 5, colMajor)
+		for i := 0; i < arr.Height(); i++ {
+			for j := 0; j < arr.Width(); j++ {
+				_ = arr.Set(i, j, i*10+j)
+			}
+		}
+		return arr
+	}
+
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			parent := newParent(colMajor)
+			v := parent.view(1, 1, 2, 3) // rows 1-2, cols 1-3
+
+			want := "Array2d[int] 2x3 [[11 12 13] [21 22 23]]"
+			if got := v.String(); got != want {
+				t.Errorf("want %q, got %q", want, got)
+			}
+
+			row, _ := v.Row(1)
+			if want := []int{21, 22, 23}; !reflect.DeepEqual(row, want) {
+				t.Errorf("Row(1): want %v, got %v", want, row)
+			}
+			col, _ := v.Col(2)
+			if want := []int{13, 23}; !reflect.DeepEqual(col, want) {
+				t.Errorf("Col(2): want %v, got %v", want, col)
+			}
+
+			// Writing through the view's mutable line (and appending to it)
+			// must only touch cells inside the view.
+			line := row
+			if colMajor {
+				line = col
+			}
+			for i := range line {
+				line[i] = -1
+			}
+			_ = append(line, -2)
+
+			if err := v.Fill(0, 2, 0, 0, 7); err != nil {
+				t.Fatalf("Fill() returned an unexpected error: %v", err)
+			}
+			for r := 0; r < parent.Height(); r++ {
+				for c := 0; c < parent.Width(); c++ {
+					got, _ := parent.Get(r, c)
+					want := r*10 + c
+					switch {
+					case r == 1 && c >= 1 && c <= 3:
+						want = 7
+					case !colMajor && r == 2 && c >= 1 && c <= 3:
+						want = -1
+					case colMajor && c == 3 && r >= 1 && r <= 2:
+						want = -1
+					}
+					if got != want {
+						t.Errorf("parent (%d, %d): want %d, got %d", r, c, want, got)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("flat operations", func(t *testing.T) {
+		parent := newParent(false)
+		v := parent.view(1, 1, 2, 2)
+
+		copied := v.Copy()
+		if Aliases(v, copied) {
+			t.Error("Copy() of a view aliases the view")
+		}
+		if want := "Array2d[int] 2x2 [[11 12] [21 22]]"; copied.String() != want {
+			t.Errorf("Copy(): want %q, got %q", want, copied.String())
+		}
+		if want := []int{11, 12, 21, 22}; !reflect.DeepEqual(v.Values(), want) {
+			t.Errorf("Values(): want %v, got %v", want, v.Values())
+		}
+
+		mapped := Map(v, func(x int) int { return -x })
+		if want := "Array2d[int] 2x2 [[-11 -12] [-21 -22]]"; mapped.String() != want {
+			t.Errorf("Map(): want %q, got %q", want, mapped.String())
+		}
+
+		v.ApplyScalar(func(cell, scalar int) int { return cell + scalar }, 100)
+		if want := "Array2d[int] 2x2 [[111 112] [121 122]]"; v.String() != want {
+			t.Errorf("ApplyScalar(): want %q, got %q", want, v.String())
+		}
+		v.SetAll(0)
+		want := "Array2d[int] 4x5 [[0 1 2 3 4] [10 0 0 13 14] [20 0 0 23 24] [30 31 32 33 34]]"
+		if got := parent.String(); got != want {
+			t.Errorf("parent after SetAll(): want %q, got %q", want, got)
+		}
+	})
+}
+
+func TestArray2D_ForEachNeighbor(t *testing.T) {
+	arr, _ := FromSlice(3, 3, []int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	t.Run("center", func(t *testing.T) {
+		calls, sum := 0, 0
+		arr.ForEachNeighbor(1, 1, 1, func(dr, dc int, v int, inBounds bool) {
+			calls++
+			if !inBounds {
+				t.Errorf("offset (%d, %d) unexpectedly out of bounds", dr, dc)
+			}
+			sum += v
+		})
+		if calls != 9 || sum != 45 {
+			t.Errorf("want 9 calls with sum 45, got %d calls with sum %d", calls, sum)
+		}
+	})
+
+	t.Run("corner", func(t *testing.T) {
+		in := 0
+		arr.ForEachNeighbor(0, 0, 1, func(dr, dc int, v int, inBounds bool) {
+			wantIn := dr >= 0 && dc >= 0
+			if inBounds != wantIn {
+				t.Errorf("offset (%d, %d): want inBounds=%v, got %v", dr, dc, wantIn, inBounds)
+			}
+			if !inBounds && v != 0 {
+				t.Errorf("offset (%d, %d): want zero value out of bounds, got %d", dr, dc, v)
+			}
+			if inBounds {
+				in++
+			}
+		})
+		if in != 4 {
+			t.Errorf("want 4 in-bounds neighbors, got %d", in)
+		}
+	})
+}
+
+func TestTrimZeroBorder(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr := New[int](4, 5, colMajor)
+		_ = arr.Set(1, 1, 1)
+		_ = arr.Set(1, 3, 2)
+		_ = arr.Set(2, 2, 3)
+
+		trimmed := TrimZeroBorder(arr)
+		want := "Array2d[int] 2x3 [[1 0 2] [0 3 0]]"
+		if got := trimmed.String(); got != want {
+			t.Errorf("colMajor=%v: want %q, got %q", colMajor, want, got)
+		}
+	}
+
+	empty := TrimZeroBorder(New[int](3, 3))
+	if empty.Height() != 0 || empty.Width() != 0 {
+		t.Errorf("want empty array for all-zero input, got %dx%d", empty.Height(), empty.Width())
+	}
+}
+
+func TestArray2D_ResizeCentered(t *testing.T) {
+	t.Run("grow", func(t *testing.T) {
+		arr, _ := FromSlice(2, 2, []int{1, 2, 3, 4})
+		got := arr.ResizeCentered(4, 4, 0)
+		want := "Array2d[int] 4x4 [[0 0 0 0] [0 1 2 0] [0 3 4 0] [0 0 0 0]]"
+		if got.String() != want {
+			t.Errorf("want %q, got %q", want, got.String())
+		}
+	})
+
+	t.Run("crop", func(t *testing.T) {
+		arr, _ := FromSlice(4, 4, []int{
+			1, 2, 3, 4,
+			5, 6, 7, 8,
+			9, 10, 11, 12,
+			13, 14, 15, 16,
+		})
+		got := arr.ResizeCentered(2, 2, 0)
+		want := "Array2d[int] 2x2 [[6 7] [10 11]]"
+		if got.String() != want {
+			t.Errorf("want %q, got %q", want, got.String())
+		}
+	})
+
+	t.Run("odd difference", func(t *testing.T) {
+		arr := NewFilled(1, 1, 1, true)
+		got := arr.ResizeCentered(2, 4, 0)
+		want := "Array2d[int] 2x4 [[0 1 0 0] [0 0 0 0]]"
+		if got.String() != want {
+			t.Errorf("want %q, got %q", want, got.String())
+		}
+	})
+}
+
+func TestArray2D_SetGrow(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr := New[int](2, 2, colMajor)
+		_ = arr.Set(0, 1, 1)
+		_ = arr.Set(1, 0, 2)
+		if err := arr.SetGrow(5, 4, 9); err != nil {
+			t.Fatalf("SetGrow() returned an unexpected error: %v", err)
+		}
+		want := "Array2d[int] 6x5 [[0 1 0 0 0] [2 0 0 0 0] [0 0 0 0 0] [0 0 0 0 0] [0 0 0 0 0] [0 0 0 0 9]]"
+		if got := arr.String(); got != want {
+			t.Errorf("colMajor=%v: want %q, got %q", colMajor, want, got)
+		}
+
+		// Setting inside the current bounds must not grow the array.
+		if err := arr.SetGrow(0, 0, 7); err != nil {
+			t.Fatalf("SetGrow() returned an unexpected error: %v", err)
+		}
+		if arr.Height() != 6 || arr.Width() != 5 {
+			t.Errorf("want 6x5 array, got %dx%d", arr.Height(), arr.Width())
+		}
+		if err := arr.SetGrow(-1, 0, 1); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("want ErrOutOfBounds for a negative index, got %v", err)
+		}
+	}
+}
+
+func TestArray2D_FillWhere(t *testing.T) {
+	arr, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6}, true) // [[1 3 5] [2 4 6]]
+	var order []int
+	n := arr.FillWhere(0, func(row, col int, current int) bool {
+		order = append(order, current)
+		return current%2 != 0
+	})
+	if n != 3 {
+		t.Errorf("want 3 cells changed, got %d", n)
+	}
+	want := "Array2d[int] 2x3 [[0 0 0] [2 4 6]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if wantOrder := []int{1, 3, 5, 2, 4, 6}; !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("want logical visiting order %v, got %v", wantOrder, order)
+	}
+}
+
+func TestArray2D_Layout(t *testing.T) {
+	check := func(t *testing.T, arr Array2D[int]) {
+		t.Helper()
+		data, offset, stride, colMajor := arr.Layout()
+		for r := 0; r < arr.Height(); r++ {
+			for c := 0; c < arr.Width(); c++ {
+				i := offset + r*stride + c
+				if colMajor {
+					i = offset + c*stride + r
+				}
+				if want, _ := arr.Get(r, c); data[i] != want {
+					t.Errorf("(%d, %d): want %d, got %d", r, c, want, data[i])
+				}
+			}
+		}
+	}
+
+	for _, colMajor := range []bool{false, true} {
+		arr := New[int](4, 5, colMajor)
+		for i := 0; i < arr.Height(); i++ {
+			for j := 0; j < arr.Width(); j++ {
+				_ = arr.Set(i, j, i*10+j)
+			}
+		}
+		t.Run(fmt.Sprintf("full colMajor=%v", colMajor), func(t *testing.T) {
+			_, offset, stride, _ := arr.Layout()
+			wantStride := 5
+			if colMajor {
+				wantStride = 4
+			}
+			if offset != 0 || stride != wantStride {
+				t.Errorf("want offset 0 and stride %d, got offset %d and stride %d", wantStride, offset, stride)
+			}
+			check(t, arr)
+		})
+		t.Run(fmt.Sprintf("view colMajor=%v", colMajor), func(t *testing.T) {
+			check(t, arr.view(1, 2, 3, 2))
+		})
+	}
+}
+
+func TestArray2D_Permute(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr := New[int](3, 3, colMajor)
+		for i := 0; i < arr.Height(); i++ {
+			for j := 0; j < arr.Width(); j++ {
+				_ = arr.Set(i, j, i*10+j)
+			}
+		}
+
+		rows, err := arr.PermuteRows([]int{2, 0, 1})
+		if err != nil {
+			t.Fatalf("PermuteRows() returned an unexpected error: %v", err)
+		}
+		if want := "Array2d[int] 3x3 [[20 21 22] [0 1 2] [10 11 12]]"; rows.String() != want {
+			t.Errorf("colMajor=%v: PermuteRows(): want %q, got %q", colMajor, want, rows.String())
+		}
+
+		cols, err := arr.PermuteCols([]int{1, 2, 0})
+		if err != nil {
+			t.Fatalf("PermuteCols() returned an unexpected error: %v", err)
+		}
+		if want := "Array2d[int] 3x3 [[1 2 0] [11 12 10] [21 22 20]]"; cols.String() != want {
+			t.Errorf("colMajor=%v: PermuteCols(): want %q, got %q", colMajor, want, cols.String())
+		}
+	}
+
+	arr := New[int](3, 2)
+	for _, perm := range [][]int{{0, 1, 1}, {0, 1}, {0, 1, 3}, {-1, 0, 1}} {
+		if _, err := arr.PermuteRows(perm); !errors.Is(err, ErrPermutation) {
+			t.Errorf("PermuteRows(%v): want ErrPermutation, got %v", perm, err)
+		}
+	}
+	if _, err := arr.PermuteCols([]int{1, 1}); !errors.Is(err, ErrPermutation) {
+		t.Errorf("PermuteCols([1 1]): want ErrPermutation, got %v", err)
+	}
+}
+
+func TestArray2D_Paste(t *testing.T) {
+	for _, srcColMajor := range []bool{false, true} {
+		dst := New[int](3, 4)
+		src := NewFilled(2, 2, 0, srcColMajor)
+		_ = src.Set(0, 0, 1)
+		_ = src.Set(0, 1, 2)
+		_ = src.Set(1, 0, 3)
+		_ = src.Set(1, 1, 4)
+
+		if err := dst.Paste(src, 1, 2); err != nil {
+			t.Fatalf("Paste() returned an unexpected error: %v", err)
+		}
+		want := "Array2d[int] 3x4 [[0 0 0 0] [0 0 1 2] [0 0 3 4]]"
+		if got := dst.String(); got != want {
+			t.Errorf("srcColMajor=%v: want %q, got %q", srcColMajor, want, got)
+		}
+
+		before := dst.String()
+		for _, at := range [][2]int{{2, 0}, {0, 3}, {-1, 0}} {
+			if err := dst.Paste(src, at[0], at[1]); !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("Paste() at %v: want ErrOutOfBounds, got %v", at, err)
+			}
+		}
+		if dst.String() != before {
+			t.Errorf("failed Paste() modified the array: %v", dst)
+		}
+	}
+}
+
+func TestArray2D_PasteOverlapping(t *testing.T) {
+	b, _ := FromSlice(3, 4, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	src, _ := b.SubArray(0, 0, 1, 2)
+	if err := b.Paste(src, 1, 1); err != nil {
+		t.Fatalf("Paste() returned an unexpected error: %v", err)
+	}
+	if want := "Array2d[int] 3x4 [[1 2 3 4] [5 1 2 3] [9 5 6 7]]"; b.String() != want {
+		t.Errorf("want %q, got %q", want, b.String())
+	}
+}
+
+func TestArray2D_ToJagged(t *testing.T) {
+	arr, _ := FromJagged(3, 3, [][]int{
+		{1, 2},
+		{},
+		{3, 0, 4},
+	})
+
+	full := arr.ToJagged(nil)
+	if want := [][]int{{1, 2, 0}, {0, 0, 0}, {3, 0, 4}}; !reflect.DeepEqual(full, want) {
+		t.Errorf("ToJagged(nil): want %v, got %v", want, full)
+	}
+	full[0][0] = 99
+	if got, _ := arr.Get(0, 0); got != 1 {
+		t.Errorf("modifying ToJagged result affected the array, got %d", got)
+	}
+
+	trimmed := arr.ToJagged(func(row []int) []int {
+		for len(row) > 0 && row[len(row)-1] == 0 {
+			row = row[:len(row)-1]
+		}
+		return row
+	})
+	if want := [][]int{{1, 2}, {}, {3, 0, 4}}; !reflect.DeepEqual(trimmed, want) {
+		t.Errorf("ToJagged(trim): want %v, got %v", want, trimmed)
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	want := []Coord{{0, 1}, {1, 0}, {1, 2}, {2, 1}}
+
+	rowMajor, _ := FromSlice(3, 3, []int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	if got := FindAll(rowMajor, isEven); !reflect.DeepEqual(got, want) {
+		t.Errorf("row-major: want %v, got %v", want, got)
+	}
+
+	colMajor, _ := FromSlice(3, 3, []int{1, 4, 7, 2, 5, 8, 3, 6, 9}, true)
+	if got := FindAll(colMajor, isEven); !reflect.DeepEqual(got, want) {
+		t.Errorf("column-major: want %v, got %v", want, got)
+	}
+
+	if got := FindAll(rowMajor, func(v int) bool { return v > 9 }); got != nil {
+		t.Errorf("want nil for no matches, got %v", got)
+	}
+}
+
+func TestArray2D_Find(t *testing.T) {
+	arr, _ := FromSlice(3, 3, []int{1, 4, 7, 2, 5, 8, 3, 6, 9}, true)
+	if row, col, ok := arr.Find(func(v int) bool { return v > 2 }); !ok || row != 0 || col != 2 {
+		t.Errorf("want (0, 2, true), got (%d, %d, %v)", row, col, ok)
+	}
+	if _, _, ok := arr.Find(func(v int) bool { return v > 9 }); ok {
+		t.Errorf("want no match")
+	}
+}
+
+func TestArray2D_FilterRows(t *testing.T) {
+	positiveSum := func(row []int) bool {
+		sum := 0
+		for _, v := range row {
+			sum += v
+		}
+		return sum > 0
+	}
+	rows := [][]int{
+		{1, 2, 3},
+		{-4, 1, 1},
+		{0, 0, 1},
+		{-1, -1, 1},
+	}
+
+	for _, colMajor := range []bool{false, true} {
+		arr, _ := FromJagged(4, 3, rows, colMajor)
+		got := arr.FilterRows(positiveSum)
+		want := "Array2d[int] 2x3 [[1 2 3] [0 0 1]]"
+		if got.String() != want {
+			t.Errorf("colMajor=%v: want %q, got %q", colMajor, want, got.String())
+		}
+		if Aliases(arr, got) {
+			t.Errorf("colMajor=%v: result shares storage with the original", colMajor)
+		}
+
+		view := arr.FilterRows(func([]int) bool { return true }).view(1, 1, 3, 2)
+		want = "Array2d[int] 2x2 [[1 1] [0 1]]"
+		if got := view.FilterRows(positiveSum).String(); got != want {
+			t.Errorf("colMajor=%v view: want %q, got %q", colMajor, want, got)
+		}
+	}
+}
+
+func TestArray2D_SetRegion(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr := New[int](3, 4, colMajor)
+		if err := arr.SetRegion(2, 3, 1, 1, []int{1, 2, 3, 4, 5, 6}); err != nil {
+			t.Fatalf("SetRegion() returned an unexpected error: %v", err)
+		}
+		want := "Array2d[int] 3x4 [[0 0 0 0] [0 1 2 3] [0 4 5 6]]"
+		if got := arr.String(); got != want {
+			t.Errorf("colMajor=%v: want %q, got %q", colMajor, want, got)
+		}
+
+		if err := arr.SetRegion(0, 0, 1, 1, []int{1, 2, 3}); !errors.Is(err, ErrDestLength) {
+			t.Errorf("want ErrDestLength, got %v", err)
+		}
+		if err := arr.SetRegion(0, 0, 3, 0, []int{1, 2, 3, 4}); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("want ErrOutOfBounds, got %v", err)
+		}
+	}
+}
+
+func TestArray2D_CopyFrom(t *testing.T) {
+	src, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+	want := "Array2d[int] 2x3 [[1 2 3] [4 5 6]]"
+
+	t.Run("same layout", func(t *testing.T) {
+		dst := New[int](2, 3)
+		if err := dst.CopyFrom(src); err != nil {
+			t.Fatalf("CopyFrom() returned an unexpected error: %v", err)
+		}
+		if got := dst.String(); got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+		if Aliases(dst, src) {
+			t.Error("CopyFrom() made the destination alias the source")
+		}
+	})
+
+	t.Run("mixed layout", func(t *testing.T) {
+		dst := New[int](2, 3, true)
+		if err := dst.CopyFrom(src); err != nil {
+			t.Fatalf("CopyFrom() returned an unexpected error: %v", err)
+		}
+		if got := dst.String(); got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("view", func(t *testing.T) {
+		parent := New[int](4, 5)
+		dst := parent.view(1, 1, 2, 3)
+		if err := dst.CopyFrom(src); err != nil {
+			t.Fatalf("CopyFrom() returned an unexpected error: %v", err)
+		}
+		wantParent := "Array2d[int] 4x5 [[0 0 0 0 0] [0 1 2 3 0] [0 4 5 6 0] [0 0 0 0 0]]"
+		if got := parent.String(); got != wantParent {
+			t.Errorf("want %q, got %q", wantParent, got)
+		}
+	})
+
+	t.Run("overlapping", func(t *testing.T) {
+		sq, _ := FromSlice(2, 2, []int{1, 2, 3, 4})
+		if err := sq.CopyFrom(sq.TransposeView()); err != nil {
+			t.Fatalf("CopyFrom() returned an unexpected error: %v", err)
+		}
+		if want := "Array2d[int] 2x2 [[1 3] [2 4]]"; sq.String() != want {
+			t.Errorf("transposed view: want %q, got %q", want, sq.String())
+		}
+
+		b, _ := FromSlice(3, 4, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+		from, _ := b.SubArray(0, 0, 1, 2)
+		to, _ := b.SubArray(1, 1, 2, 3)
+		if err := to.CopyFrom(from); err != nil {
+			t.Fatalf("CopyFrom() returned an unexpected error: %v", err)
+		}
+		if want := "Array2d[int] 3x4 [[1 2 3 4] [5 1 2 3] [9 5 6 7]]"; b.String() != want {
+			t.Errorf("overlapping views: want %q, got %q", want, b.String())
+		}
+	})
+
+	t.Run("shape mismatch", func(t *testing.T) {
+		if err := New[int](3, 2).CopyFrom(src); !errors.Is(err, ErrShape) {
+			t.Errorf("want error to be ErrShape, but it was not. got: %v", err)
+		}
+	})
+}
+
+func TestHistogram(t *testing.T) {
+	arr, _ := FromSlice(2, 3, []string{"grass", "water", "grass", "rock", "grass", "water"}, true)
+	got := Histogram(arr)
+	want := map[string]int{"grass": 3, "water": 2, "rock": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestSparsity(t *testing.T) {
+	arr, _ := FromSlice(2, 2, []int{0, 3, 0, 5})
+	if got := Sparsity(arr); got != 0.5 {
+		t.Errorf("want 0.5, got %v", got)
+	}
+	if got := Sparsity(New[int](2, 2)); got != 1 {
+		t.Errorf("all zero: want 1, got %v", got)
+	}
+	if got := Sparsity(New[int](0, 3)); got != 0 {
+		t.Errorf("empty: want 0, got %v", got)
+	}
+}
+
+func TestArray2D_chaining(t *testing.T) {
+	arr := New[int](3, 3).FillV(0, 0, 2, 2, 1).SetV(1, 1, 9).FillV(2, 0, 2, 1, 0)
+	want := "Array2d[int] 3x3 [[1 1 1] [1 9 1] [0 0 1]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("want panic with ErrOutOfBounds, got %v", err)
+		}
+	}()
+	arr.SetV(3, 0, 1)
+}
+
+func TestJoinCols(t *testing.T) {
+	a, _ := FromSlice(2, 1, []int{1, 2})
+	b, _ := FromSlice(2, 2, []int{3, 4, 5, 6})
+	c, _ := FromSlice(2, 3, []int{7, 8, 9, 10, 11, 12}, true)
+
+	got, err := JoinCols([]Array2D[int]{a, b, c})
+	if err != nil {
+		t.Fatalf("JoinCols() returned an unexpected error: %v", err)
+	}
+	want := "Array2d[int] 2x6 [[1 3 4 7 9 11] [2 5 6 8 10 12]]"
+	if got.String() != want {
+		t.Errorf("want %q, got %q", want, got.String())
+	}
+
+	_, err = JoinCols([]Array2D[int]{a, b, New[int](3, 1)})
+	if !errors.Is(err, ErrShape) {
+		t.Fatalf("want error to be ErrShape, but it was not. got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "array 2") {
+		t.Errorf("want error to name the offending array index, got %q", err)
+	}
+}
+
+func TestArray2D_Recenter(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr := New[int](3, 3, colMajor)
+		for i := 0; i < arr.Height(); i++ {
+			for j := 0; j < arr.Width(); j++ {
+				_ = arr.Set(i, j, i*3+j+1)
+			}
+		}
+
+		got, err := arr.Recenter(1, 1)
+		if err != nil {
+			t.Fatalf("Recenter() returned an unexpected error: %v", err)
+		}
+		want := "Array2d[int] 3x3 [[5 6 4] [8 9 7] [2 3 1]]"
+		if got.String() != want {
+			t.Errorf("colMajor=%v: want %q, got %q", colMajor, want, got.String())
+		}
+
+		got, _ = arr.Recenter(2, 0)
+		want = "Array2d[int] 3x3 [[7 8 9] [1 2 3] [4 5 6]]"
+		if got.String() != want {
+			t.Errorf("colMajor=%v: want %q, got %q", colMajor, want, got.String())
+		}
+	}
+
+	if _, err := New[int](2, 2).Recenter(0, 2); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("want ErrOutOfBounds, got %v", err)
+	}
+}
+
+func TestRows_Row(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr, _ := FromJagged(2, 2, [][]int{{1, 2}, {3, 4}}, colMajor)
+		rows := arr.Rows()
+		if _, ok := rows.Row(); ok {
+			t.Error("Row() before Next returned ok=true")
+		}
+		for rows.Next() {
+			row, ok := rows.Row()
+			if !ok {
+				t.Fatalf("Row() returned ok=false at index %d", rows.Index())
+			}
+			if want := []int{rows.Index()*2 + 1, rows.Index()*2 + 2}; !reflect.DeepEqual(row, want) {
+				t.Errorf("row %d: want %v, got %v", rows.Index(), want, row)
+			}
+			row[0] = -1
+		}
+
+		got, _ := arr.Get(1, 0)
+		if !colMajor && got != -1 {
+			t.Errorf("row-major: Row() did not alias the array, got %d", got)
+		}
+		if colMajor && got != 3 {
+			t.Errorf("column-major: Row() aliased the array, got %d", got)
+		}
+	}
+
+	arr := New[int](100, 100)
+	rows := arr.Rows()
+	allocs := testing.AllocsPerRun(10, func() {
+		rows.row = -1
+		for rows.Next() {
+			_, _ = rows.Row()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("want zero allocations for row-major iteration, got %v", allocs)
+	}
+}
+
+func TestArray2D_Validate(t *testing.T) {
+	valid, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+	for name, arr := range map[string]Array2D[int]{
+		"zero value":  {},
+		"from slice":  valid,
+		"column view": New[int](4, 5, true).view(1, 1, 2, 3),
+		"empty":       New[int](0, 3),
+	} {
+		if err := arr.Validate(); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+
+	short := valid
+	short.slice = short.slice[:5] // backing slice shorter than height*width
+	narrowStride := valid
+	narrowStride.stride = 2
+	farView := valid.view(1, 2, 1, 2)
+	for name, arr := range map[string]Array2D[int]{
+		"negative dimensions": New[int](-1, -2),
+		"short slice":         short,
+		"narrow stride":       narrowStride,
+		"view past the end":   farView,
+	} {
+		if err := arr.Validate(); !errors.Is(err, ErrShape) {
+			t.Errorf("%s: want error to be ErrShape, but it was not. got: %v", name, err)
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, colMajor)
+		mask := Mask(arr, func(v int) bool { return v%2 == 0 })
+		want := "Array2d[bool] 2x3 [[false true false] [true false true]]"
+		if got := mask.String(); got != want {
+			t.Errorf("colMajor=%v: want %q, got %q", colMajor, want, got)
+		}
+		if _, _, _, gotColMajor := mask.Layout(); gotColMajor != colMajor {
+			t.Errorf("want colMajor=%v preserved, got %v", colMajor, gotColMajor)
+		}
+	}
+}
+
+func TestArray2D_ApplyMask(t *testing.T) {
+	arr, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+	mask := Mask(arr.Copy(), func(v int) bool { return v%2 == 0 })
+	colMask := New[bool](2, 3, true)
+	_ = colMask.CopyFrom(mask)
+
+	if err := arr.ApplyMask(colMask, -1); err != nil {
+		t.Fatalf("ApplyMask() returned an unexpected error: %v", err)
+	}
+	want := "Array2d[int] 2x3 [[1 -1 3] [-1 5 -1]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	if err := arr.ApplyMask(New[bool](3, 2), 0); !errors.Is(err, ErrShape) {
+		t.Errorf("want error to be ErrShape, but it was not. got: %v", err)
+	}
+}
+
+func TestArray2D_DiagonalOffset(t *testing.T) {
+	arr, _ := FromSlice(3, 4, []int{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	})
+
+	for k, want := range map[int][]int{
+		0:  {1, 6, 11},
+		1:  {2, 7, 12},
+		3:  {4},
+		-1: {5, 10},
+		-2: {9},
+	} {
+		got, err := arr.DiagonalOffset(k)
+		if err != nil {
+			t.Fatalf("DiagonalOffset(%d) returned an unexpected error: %v", k, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DiagonalOffset(%d): want %v, got %v", k, want, got)
+		}
+	}
+
+	for _, k := range []int{4, -3} {
+		if _, err := arr.DiagonalOffset(k); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("DiagonalOffset(%d): want ErrOutOfBounds, got %v", k, err)
+		}
+	}
+}
+
+func TestArray2D_FillPattern(t *testing.T) {
+	pattern, _ := FromSlice(2, 2, []int{1, 2, 3, 4})
+
+	arr := New[int](4, 4, true)
+	arr.FillPattern(pattern)
+	want := "Array2d[int] 4x4 [[1 2 1 2] [3 4 3 4] [1 2 1 2] [3 4 3 4]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	small := New[int](1, 1)
+	small.FillPattern(pattern)
+	if got, _ := small.Get(0, 0); got != 1 {
+		t.Errorf("larger pattern: want top-left value 1, got %d", got)
+	}
+
+	arr.FillPattern(New[int](0, 0))
+	if got := arr.String(); got != want {
+		t.Errorf("empty pattern modified the array: %q", got)
+	}
+}
+
+func TestArray2D_Transpose(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, colMajor)
+			tr := arr.Transpose()
+			want := "Array2d[int] 3x2 [[1 4] [2 5] [3 6]]"
+			if got := tr.String(); got != want {
+				t.Errorf("want %q, got %q", want, got)
+			}
+			tr.Set(0, 0, 100)
+			if v, _ := arr.Get(0, 0); v != 1 {
+				t.Errorf("transpose shares storage with the original")
+			}
+		})
+	}
+}
+
+func TestArray2D_TransposeView(t *testing.T) {
+	arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}})
+	tv := arr.TransposeView()
+
+	want := "Array2d[int] 3x2 [[1 4] [2 5] [3 6]]"
+	if got := tv.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if row, _ := tv.Row(2); !reflect.DeepEqual(row, []int{3, 6}) {
+		t.Errorf("Row(2): want [3 6], got %v", row)
+	}
+	if col, _ := tv.Col(1); !reflect.DeepEqual(col, []int{4, 5, 6}) {
+		t.Errorf("Col(1): want [4 5 6], got %v", col)
+	}
+
+	tv.Set(2, 0, 30)
+	if v, _ := arr.Get(0, 2); v != 30 {
+		t.Errorf("write through view: want 30, got %d", v)
+	}
+	if !Aliases(arr, tv) {
+		t.Errorf("view does not share storage with the original")
+	}
+
+	sub := arr.view(0, 1, 2, 2).TransposeView()
+	want = "Array2d[int] 2x2 [[2 5] [30 6]]"
+	if got := sub.String(); got != want {
+		t.Errorf("transposed sub-view: want %q, got %q", want, got)
+	}
+	if got := tv.TransposeView().String(); got != arr.String() {
+		t.Errorf("double transpose: want %q, got %q", arr.String(), got)
+	}
+}
+
+func TestArray2D_SubArray(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(3, 4, [][]int{
+				{1, 2, 3, 4},
+				{5, 6, 7, 8},
+				{9, 10, 11, 12},
+			}, colMajor)
+
+			sub, err := arr.SubArray(2, 2, 1, 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := "Array2d[int] 2x2 [[6 7] [10 11]]"
+			if got := sub.String(); got != want {
+				t.Errorf("want %q, got %q", want, got)
+			}
+
+			sub.Set(0, 1, 70)
+			if v, _ := arr.Get(1, 2); v != 70 {
+				t.Errorf("write through view: want 70, got %d", v)
+			}
+			arr.Set(2, 1, 100)
+			if v, _ := sub.Get(1, 0); v != 100 {
+				t.Errorf("write to parent: want 100, got %d", v)
+			}
+			if err := sub.Validate(); err != nil {
+				t.Errorf("invalid view: %v", err)
+			}
+		})
+	}
+
+	t.Run("out of bounds", func(t *testing.T) {
+		arr := New[int](2, 2)
+		if _, err := arr.SubArray(0, 0, 2, 1); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("want ErrOutOfBounds, got %v", err)
+		}
+	})
+}
+
+func TestArray2D_Flatten(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, colMajor)
+
+			if got, want := arr.Flatten(true), []int{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(got, want) {
+				t.Errorf("row order: want %v, got %v", want, got)
+			}
+			if got, want := arr.Flatten(false), []int{1, 4, 2, 5, 3, 6}; !reflect.DeepEqual(got, want) {
+				t.Errorf("column order: want %v, got %v", want, got)
+			}
+			if got, want := arr.view(0, 1, 2, 2).Flatten(true), []int{2, 3, 5, 6}; !reflect.DeepEqual(got, want) {
+				t.Errorf("view: want %v, got %v", want, got)
+			}
+
+			flat := arr.Flatten(!colMajor)
+			flat[0] = 100
+			if v, _ := arr.Get(0, 0); v != 1 {
+				t.Errorf("Flatten aliases the array")
+			}
+		})
+	}
+}
+
+func TestArray2D_Diagonal(t *testing.T) {
+	arr, _ := FromJagged(3, 4, [][]int{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{9, 10, 11, 12},
+	}, true)
+
+	if got, want := arr.Diagonal(), []int{1, 6, 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagonal: want %v, got %v", want, got)
+	}
+	if got, want := arr.AntiDiagonal(), []int{4, 7, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AntiDiagonal: want %v, got %v", want, got)
+	}
+
+	if err := arr.SetDiagonal([]int{0, 0, 0}); err != nil {
+		t.Fatalf("SetDiagonal: unexpected error: %v", err)
+	}
+	if err := arr.SetAntiDiagonal([]int{-1, -2, -3}); err != nil {
+		t.Fatalf("SetAntiDiagonal: unexpected error: %v", err)
+	}
+	want := "Array2d[int] 3x4 [[0 2 3 -1] [5 0 -2 8] [9 -3 0 12]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	if err := arr.SetDiagonal([]int{1}); !errors.Is(err, ErrDestLength) {
+		t.Errorf("want ErrDestLength, got %v", err)
+	}
+	if got := New[int](0, 3).Diagonal(); len(got) != 0 {
+		t.Errorf("empty array: want empty diagonal, got %v", got)
+	}
+}
+
+func TestArray2D_SwapRowsCols(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(3, 3, [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, colMajor)
+
+			if err := arr.SwapRows(0, 2); err != nil {
+				t.Fatalf("SwapRows: unexpected error: %v", err)
+			}
+			want := "Array2d[int] 3x3 [[7 8 9] [4 5 6] [1 2 3]]"
+			if got := arr.String(); got != want {
+				t.Errorf("SwapRows: want %q, got %q", want, got)
+			}
+
+			if err := arr.SwapCols(1, 0); err != nil {
+				t.Fatalf("SwapCols: unexpected error: %v", err)
+			}
+			want = "Array2d[int] 3x3 [[8 7 9] [5 4 6] [2 1 3]]"
+			if got := arr.String(); got != want {
+				t.Errorf("SwapCols: want %q, got %q", want, got)
+			}
+
+			sub := arr.view(1, 1, 2, 2)
+			_ = sub.SwapRows(0, 1)
+			want = "Array2d[int] 3x3 [[8 7 9] [5 1 3] [2 4 6]]"
+			if got := arr.String(); got != want {
+				t.Errorf("view: want %q, got %q", want, got)
+			}
+
+			if err := arr.SwapRows(0, 3); !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("SwapRows: want ErrOutOfBounds, got %v", err)
+			}
+			if err := arr.SwapCols(-1, 0); !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("SwapCols: want ErrOutOfBounds, got %v", err)
+			}
+		})
+	}
+}
+
+func TestArray2D_InsertDeleteRow(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, colMajor)
+
+			inserted, err := arr.InsertRow(1, []int{7, 8, 9})
+			if err != nil {
+				t.Fatalf("InsertRow: unexpected error: %v", err)
+			}
+			want := "Array2d[int] 3x3 [[1 2 3] [7 8 9] [4 5 6]]"
+			if got := inserted.String(); got != want {
+				t.Errorf("InsertRow: want %q, got %q", want, got)
+			}
+
+			appended, _ := arr.InsertRow(2, []int{0, 0, 0})
+			want = "Array2d[int] 3x3 [[1 2 3] [4 5 6] [0 0 0]]"
+			if got := appended.String(); got != want {
+				t.Errorf("append: want %q, got %q", want, got)
+			}
+
+			deleted, err := inserted.DeleteRow(0)
+			if err != nil {
+				t.Fatalf("DeleteRow: unexpected error: %v", err)
+			}
+			want = "Array2d[int] 2x3 [[7 8 9] [4 5 6]]"
+			if got := deleted.String(); got != want {
+				t.Errorf("DeleteRow: want %q, got %q", want, got)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		arr := New[int](2, 3)
+		if _, err := arr.InsertRow(3, []int{1, 2, 3}); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("InsertRow: want ErrOutOfBounds, got %v", err)
+		}
+		if _, err := arr.InsertRow(0, []int{1, 2}); !errors.Is(err, ErrShape) {
+			t.Errorf("InsertRow: want ErrShape, got %v", err)
+		}
+		if _, err := arr.DeleteRow(2); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("DeleteRow: want ErrOutOfBounds, got %v", err)
+		}
+	})
+}
+
+func TestArray2D_InsertDeleteCol(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, colMajor)
+
+			inserted, err := arr.InsertCol(0, []int{7, 8})
+			if err != nil {
+				t.Fatalf("InsertCol: unexpected error: %v", err)
+			}
+			want := "Array2d[int] 2x4 [[7 1 2 3] [8 4 5 6]]"
+			if got := inserted.String(); got != want {
+				t.Errorf("InsertCol: want %q, got %q", want, got)
+			}
+
+			appended, _ := arr.InsertCol(3, []int{0, 0})
+			want = "Array2d[int] 2x4 [[1 2 3 0] [4 5 6 0]]"
+			if got := appended.String(); got != want {
+				t.Errorf("append: want %q, got %q", want, got)
+			}
+
+			deleted, err := inserted.DeleteCol(2)
+			if err != nil {
+				t.Fatalf("DeleteCol: unexpected error: %v", err)
+			}
+			want = "Array2d[int] 2x3 [[7 1 3] [8 4 6]]"
+			if got := deleted.String(); got != want {
+				t.Errorf("DeleteCol: want %q, got %q", want, got)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		arr := New[int](2, 3)
+		if _, err := arr.InsertCol(4, []int{1, 2}); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("InsertCol: want ErrOutOfBounds, got %v", err)
+		}
+		if _, err := arr.InsertCol(0, []int{1, 2, 3}); !errors.Is(err, ErrShape) {
+			t.Errorf("InsertCol: want ErrShape, got %v", err)
+		}
+		if _, err := arr.DeleteCol(-1); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("DeleteCol: want ErrOutOfBounds, got %v", err)
+		}
+	})
+}
+
+func TestArray2D_Resize(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, colMajor)
+
+			grown := arr.Resize(3, 4, -1)
+			want := "Array2d[int] 3x4 [[1 2 3 -1] [4 5 6 -1] [-1 -1 -1 -1]]"
+			if got := grown.String(); got != want {
+				t.Errorf("grow: want %q, got %q", want, got)
+			}
+
+			shrunk := arr.Resize(1, 2, -1)
+			want = "Array2d[int] 1x2 [[1 2]]"
+			if got := shrunk.String(); got != want {
+				t.Errorf("shrink: want %q, got %q", want, got)
+			}
+
+			mixed := arr.Resize(3, 1, 0)
+			want = "Array2d[int] 3x1 [[1] [4] [0]]"
+			if got := mixed.String(); got != want {
+				t.Errorf("mixed: want %q, got %q", want, got)
+			}
+			if Aliases(arr, grown) {
+				t.Errorf("Resize result shares storage with the original")
+			}
+		})
+	}
+}
+
+func TestArray2D_Flip(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(3, 3, [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, colMajor)
+			orig := arr.String()
+
+			want := "Array2d[int] 3x3 [[3 2 1] [6 5 4] [9 8 7]]"
+			if got := arr.FlipHorizontal().String(); got != want {
+				t.Errorf("FlipHorizontal: want %q, got %q", want, got)
+			}
+			want = "Array2d[int] 3x3 [[7 8 9] [4 5 6] [1 2 3]]"
+			if got := arr.FlipVertical().String(); got != want {
+				t.Errorf("FlipVertical: want %q, got %q", want, got)
+			}
+			if got := arr.String(); got != orig {
+				t.Errorf("copying flips modified the original: %q", got)
+			}
+
+			arr.FlipHorizontalInPlace()
+			arr.FlipVerticalInPlace()
+			want = "Array2d[int] 3x3 [[9 8 7] [6 5 4] [3 2 1]]"
+			if got := arr.String(); got != want {
+				t.Errorf("in place: want %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestArray2D_RollShift(t *testing.T) {
+	arr, _ := FromJagged(3, 3, [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, true)
+
+	tests := []struct {
+		name string
+		got  Array2D[int]
+		want string
+	}{
+		{"RollRows", arr.RollRows(1), "Array2d[int] 3x3 [[7 8 9] [1 2 3] [4 5 6]]"},
+		{"RollRows negative", arr.RollRows(-4), "Array2d[int] 3x3 [[4 5 6] [7 8 9] [1 2 3]]"},
+		{"RollCols", arr.RollCols(2), "Array2d[int] 3x3 [[2 3 1] [5 6 4] [8 9 7]]"},
+		{"ShiftRows", arr.ShiftRows(2, 0), "Array2d[int] 3x3 [[0 0 0] [0 0 0] [1 2 3]]"},
+		{"ShiftRows negative", arr.ShiftRows(-1, 0), "Array2d[int] 3x3 [[4 5 6] [7 8 9] [0 0 0]]"},
+		{"ShiftCols", arr.ShiftCols(1, -1), "Array2d[int] 3x3 [[-1 1 2] [-1 4 5] [-1 7 8]]"},
+		{"ShiftCols past edge", arr.ShiftCols(-5, -1), "Array2d[int] 3x3 [[-1 -1 -1] [-1 -1 -1] [-1 -1 -1]]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArray2D_MapInPlace(t *testing.T) {
+	arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, true)
+	arr.view(0, 1, 2, 2).MapInPlace(func(v int) int { return v * 10 })
+	want := "Array2d[int] 2x3 [[1 20 30] [4 50 60]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestMapIndexed(t *testing.T) {
+	arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, true)
+	result := MapIndexed(arr, func(row, col int, v int) string {
+		return fmt.Sprintf("%d,%d=%d", row, col, v)
+	})
+	want := "Array2d[string] 2x3 [[0,0=1 0,1=2 0,2=3] [1,0=4 1,1=5 1,2=6]]"
+	if got := result.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, true)
+	concat := func(acc string, v int) string { return acc + fmt.Sprint(v) }
+
+	if got := Reduce(arr, ">", concat); got != ">123456" {
+		t.Errorf("Reduce: want %q, got %q", ">123456", got)
+	}
+	if got, want := ReduceRows(arr, "", concat), []string{"123", "456"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReduceRows: want %v, got %v", want, got)
+	}
+	if got, want := ReduceCols(arr, "", concat), []string{"14", "25", "36"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReduceCols: want %v, got %v", want, got)
+	}
+}
+
+func TestArray2D_ForEach(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(2, 2, [][]int{{1, 2}, {3, 4}}, colMajor)
+
+			var values []int
+			arr.ForEach(func(v int) { values = append(values, v) })
+			want := []int{1, 2, 3, 4}
+			if colMajor {
+				want = []int{1, 3, 2, 4}
+			}
+			if !reflect.DeepEqual(values, want) {
+				t.Errorf("ForEach: want %v, got %v", want, values)
+			}
+
+			arr.ForEachIndexed(func(row, col int, v int) {
+				if got, _ := arr.Get(row, col); got != v {
+					t.Errorf("ForEachIndexed: (%d, %d) passed %d, but holds %d", row, col, v, got)
+				}
+			})
+		})
+	}
+}
+
+func TestArray2D_AnyAllCount(t *testing.T) {
+	arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}})
+	greater := func(n int) func(int) bool {
+		return func(v int) bool { return v > n }
+	}
+
+	if !arr.Any(greater(5)) || arr.Any(greater(6)) {
+		t.Errorf("Any: unexpected result")
+	}
+	if !arr.All(greater(0)) || arr.All(greater(1)) {
+		t.Errorf("All: unexpected result")
+	}
+	if got := arr.Count(greater(2)); got != 4 {
+		t.Errorf("Count: want 4, got %d", got)
+	}
+
+	calls := 0
+	arr.Any(func(v int) bool {
+		calls++
+		return v == 2
+	})
+	if calls != 2 {
+		t.Errorf("Any: want early exit after 2 calls, got %d", calls)
+	}
+
+	empty := New[int](0, 0)
+	if empty.Any(greater(0)) || !empty.All(greater(0)) || empty.Count(greater(0)) != 0 {
+		t.Errorf("empty array: unexpected result")
+	}
+}
@@ -0,0 +1,69 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBitArray2D(t *testing.T) {
+	const height, width = 7, 20 // 140 cells span three words
+	ref := New[bool](height, width)
+	bitArr := NewBitArray(height, width)
+
+	if got, want := len(bitArr.words), 3; got != want {
+		t.Errorf("want %d packed words, got %d", want, got)
+	}
+
+	apply := func(name string, refErr, bitErr error) {
+		t.Helper()
+		if refErr != nil || bitErr != nil {
+			t.Fatalf("%s: unexpected errors: %v, %v", name, refErr, bitErr)
+		}
+	}
+	apply("Fill", ref.Fill(1, 2, 5, 17, true), bitArr.Fill(1, 2, 5, 17, true))
+	apply("Fill", ref.Fill(3, 19, 2, 0, false), bitArr.Fill(3, 19, 2, 0, false))
+	apply("Set", ref.Set(0, 0, true), bitArr.Set(0, 0, true))
+	apply("Set", ref.Set(6, 19, true), bitArr.Set(6, 19, true))
+	apply("Set", ref.Set(4, 10, false), bitArr.Set(4, 10, false))
+
+	for r := 0; r < height; r++ {
+		for c := 0; c < width; c++ {
+			want, _ := ref.Get(r, c)
+			if got, ok := bitArr.Get(r, c); !ok || got != want {
+				t.Errorf("Get(%d, %d): want (%v, true), got (%v, %v)", r, c, want, got, ok)
+			}
+		}
+	}
+
+	if got, want := bitArr.CountRows(), CountRows(ref, true); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountRows(): want %v, got %v", want, got)
+	}
+	wantCount := 0
+	for _, n := range CountRows(ref, true) {
+		wantCount += n
+	}
+	if got := bitArr.Count(); got != wantCount {
+		t.Errorf("Count(): want %d, got %d", wantCount, got)
+	}
+
+	if got := bitArr.ToArray(); got.String() != ref.String() {
+		t.Errorf("ToArray(): want %v, got %v", ref, got)
+	}
+	if got := ToBitArray(ref); !reflect.DeepEqual(got, bitArr) {
+		t.Errorf("ToBitArray(): want %v, got %v", bitArr, got)
+	}
+
+	if _, ok := bitArr.Get(height, 0); ok {
+		t.Error("Get() out of bounds returned ok=true")
+	}
+	if err := bitArr.Set(0, width, true); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("want ErrOutOfBounds, got %v", err)
+	}
+	if err := bitArr.Fill(0, 0, height, 0, true); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("want ErrOutOfBounds, got %v", err)
+	}
+}
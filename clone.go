@@ -0,0 +1,102 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import "reflect"
+
+// CloneDeep returns a deep copy of this array.
+//
+// If T contains no references (slices, maps, pointers, interfaces, channels or
+// functions, directly or within arrays and structs), it is as cheap as Copy.
+// Otherwise every element is copied recursively using reflection: slices, maps
+// and pointed-to values get fresh storage so the clone shares nothing with the
+// original. Reflection is considerably slower than Copy, so prefer Map with a
+// type-specific cloner in hot paths.
+//
+// Unexported struct fields, channels and functions are copied shallowly.
+// Cyclic data structures are not supported.
+func (a Array2D[T]) CloneDeep() Array2D[T] {
+	result := a.Copy()
+	if !hasReferences(reflect.TypeOf((*T)(nil)).Elem()) {
+		return result
+	}
+	for i := range result.slice {
+		elem := reflect.ValueOf(&result.slice[i]).Elem()
+		elem.Set(deepCopy(elem))
+	}
+	return result
+}
+
+// hasReferences reports whether values of type t can refer to shared memory.
+func hasReferences(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface,
+		reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	case reflect.Array:
+		return hasReferences(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasReferences(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deepCopy returns a recursive copy of v.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(deepCopy(iter.Key()), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := c.Field(i); f.CanSet() {
+				f.Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	}
+	return v
+}
@@ -0,0 +1,74 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArray2D_CloneDeep(t *testing.T) {
+	t.Run("slices", func(t *testing.T) {
+		arr := New[[]int](2, 2)
+		_ = arr.Set(0, 0, []int{1, 2})
+		_ = arr.Set(1, 1, []int{3})
+
+		clone := arr.CloneDeep()
+		if !reflect.DeepEqual(clone.Values(), arr.Values()) {
+			t.Fatalf("CloneDeep(): want %v, got %v", arr, clone)
+		}
+		inner, _ := clone.Get(0, 0)
+		inner[0] = 99
+		if got, _ := arr.Get(0, 0); got[0] != 1 {
+			t.Errorf("modifying a cloned inner slice affected the original: %v", got)
+		}
+	})
+
+	t.Run("nested references", func(t *testing.T) {
+		type item struct {
+			Tags  map[string][]int
+			Next  *int
+			Value any
+		}
+		n := 5
+		arr := New[item](1, 1, true)
+		_ = arr.Set(0, 0, item{Tags: map[string][]int{"a": {1}}, Next: &n, Value: []string{"x"}})
+
+		clone := arr.CloneDeep()
+		got, _ := clone.Get(0, 0)
+		got.Tags["a"][0] = 2
+		*got.Next = 6
+		got.Value.([]string)[0] = "y"
+
+		orig, _ := arr.Get(0, 0)
+		if orig.Tags["a"][0] != 1 || *orig.Next != 5 || orig.Value.([]string)[0] != "x" {
+			t.Errorf("modifying the clone affected the original: %+v", orig)
+		}
+	})
+
+	t.Run("interfaces with nil cells", func(t *testing.T) {
+		arr := New[any](1, 2)
+		_ = arr.Set(0, 1, []int{1})
+		clone := arr.CloneDeep()
+		if v, _ := clone.Get(0, 0); v != nil {
+			t.Errorf("want nil cell preserved, got %v", v)
+		}
+		v, _ := clone.Get(0, 1)
+		v.([]int)[0] = 2
+		if orig, _ := arr.Get(0, 1); orig.([]int)[0] != 1 {
+			t.Errorf("modifying the clone affected the original: %v", orig)
+		}
+	})
+
+	t.Run("values", func(t *testing.T) {
+		arr, _ := FromSlice(2, 2, []int{1, 2, 3, 4})
+		clone := arr.CloneDeep()
+		if Aliases(arr, clone) {
+			t.Error("CloneDeep() result aliases the original")
+		}
+		if clone.String() != arr.String() {
+			t.Errorf("CloneDeep(): want %v, got %v", arr, clone)
+		}
+	})
+}
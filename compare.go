@@ -0,0 +1,108 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"fmt"
+	"math"
+)
+
+// CellDiff describes a cell whose value differs between two arrays: Old is the
+// value in the first array and New the value in the second.
+type CellDiff[T any] struct {
+	Row, Col int
+	Old, New T
+}
+
+// String formats the difference as "(row, col): old -> new", for use in test
+// failure messages and change logs.
+func (d CellDiff[T]) String() string {
+	return fmt.Sprintf("(%d, %d): %v -> %v", d.Row, d.Col, d.Old, d.New)
+}
+
+// Diff returns the cells at which a and b hold different values, in logical
+// row-major order, with a's value as Old and b's value as New.
+// It returns ErrShape if the arrays do not have the same dimensions.
+//
+// The arrays are compared by logical position, so a row-major and a
+// column-major array with the same contents have no differences.
+func Diff[T comparable](a, b Array2D[T]) ([]CellDiff[T], error) {
+	if a.height != b.height || a.width != b.width {
+		return nil, fmt.Errorf("%w: %dx%d does not match %dx%d", ErrShape, a.height, a.width, b.height, b.width)
+	}
+	var diffs []CellDiff[T]
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if av, bv := a.getUnchecked(r, c), b.getUnchecked(r, c); av != bv {
+				diffs = append(diffs, CellDiff[T]{Row: r, Col: c, Old: av, New: bv})
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// Equal reports whether a and b have the same dimensions and hold equal values
+// at every position. The arrays are compared by logical position, so a
+// row-major and a column-major array with the same contents are equal.
+func Equal[T comparable](a, b Array2D[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualFunc is like Equal but compares the values at each position with eq.
+func EqualFunc[T1, T2 any](a Array2D[T1], b Array2D[T2], eq func(T1, T2) bool) bool {
+	if a.height != b.height || a.width != b.width {
+		return false
+	}
+	if a.colMajor == b.colMajor {
+		count, _ := a.lines()
+		for i := 0; i < count; i++ {
+			y := b.line(i)
+			for j, x := range a.line(i) {
+				if !eq(x, y[j]) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if !eq(a.getUnchecked(r, c), b.getUnchecked(r, c)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// EqualApprox reports whether a and b have the same dimensions and every pair
+// of values at the same position differs by at most tol. NaN values are never
+// equal, and an infinity is only equal to an infinity of the same sign.
+func EqualApprox[F Float](a, b Array2D[F], tol F) bool {
+	return EqualApproxRel(a, b, tol, 0)
+}
+
+// EqualApproxRel is like EqualApprox but also accepts values whose difference
+// is within relTol times the larger of their magnitudes. Two values x and y
+// are considered equal if
+//
+//	|x - y| <= max(absTol, relTol * max(|x|, |y|))
+//
+// A relative tolerance suits values of widely varying magnitude, where a
+// fixed absolute tolerance is either too strict or too loose. As with
+// EqualApprox, NaN values are never equal and an infinity is only equal to an
+// infinity of the same sign, whatever the tolerances.
+func EqualApproxRel[F Float](a, b Array2D[F], absTol, relTol F) bool {
+	return EqualFunc(a, b, func(x, y F) bool {
+		if x == y {
+			return true
+		}
+		if math.IsInf(float64(x), 0) || math.IsInf(float64(y), 0) {
+			return false
+		}
+		diff := math.Abs(float64(x) - float64(y))
+		scale := math.Max(math.Abs(float64(x)), math.Abs(float64(y)))
+		return diff <= math.Max(float64(absTol), float64(relTol)*scale)
+	})
+}
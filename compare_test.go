@@ -0,0 +1,92 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+	b, _ := FromSlice(2, 3, []int{1, 4, 2, 2, 3, 7}, true) // [[1 2 3] [4 2 7]]
+
+	got, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("Diff() returned an unexpected error: %v", err)
+	}
+	want := []CellDiff[int]{
+		{Row: 1, Col: 1, Old: 5, New: 2},
+		{Row: 1, Col: 2, Old: 6, New: 7},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff(): want %v, got %v", want, got)
+	}
+	if s := got[1].String(); s != "(1, 2): 6 -> 7" {
+		t.Errorf("String(): want %q, got %q", "(1, 2): 6 -> 7", s)
+	}
+
+	if got, _ := Diff(a, a.Copy()); got != nil {
+		t.Errorf("Diff() of identical arrays: want nil, got %v", got)
+	}
+
+	if _, err := Diff(a, New[int](3, 2)); !errors.Is(err, ErrShape) {
+		t.Errorf("want error to be ErrShape, but it was not. got: %v", err)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+	b, _ := FromSlice(2, 3, []int{1, 4, 2, 5, 3, 6}, true)
+
+	if !Equal(a, b) {
+		t.Errorf("want arrays with different layouts but the same contents to be equal")
+	}
+	if !Equal(a.view(0, 1, 2, 2), b.view(0, 1, 2, 2)) {
+		t.Errorf("want equal views to be equal")
+	}
+	b.Set(1, 2, 0)
+	if Equal(a, b) {
+		t.Errorf("want arrays with different contents to differ")
+	}
+	if Equal(a, New[int](3, 2)) {
+		t.Errorf("want arrays with different shapes to differ")
+	}
+
+	strs := Map(a, func(v int) string { return fmt.Sprint(v) })
+	if !EqualFunc(a, strs, func(x int, s string) bool { return fmt.Sprint(x) == s }) {
+		t.Errorf("EqualFunc: want equal")
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	a, _ := FromSlice(1, 3, []float64{1, 1000, math.Inf(1)})
+	b, _ := FromSlice(1, 3, []float64{1.001, 1001, math.Inf(1)})
+
+	if !EqualApprox(a, b, 1) {
+		t.Errorf("want equal within absolute tolerance 1")
+	}
+	if EqualApprox(a, b, 0.01) {
+		t.Errorf("want unequal within absolute tolerance 0.01")
+	}
+	if !EqualApproxRel(a, b, 0.01, 0.001) {
+		t.Errorf("want equal within relative tolerance 0.001")
+	}
+
+	inf, _ := FromSlice(1, 2, []float64{math.Inf(1), math.Inf(1)})
+	for _, other := range [][]float64{{math.Inf(1), 1}, {math.Inf(1), math.Inf(-1)}} {
+		b, _ := FromSlice(1, 2, other)
+		if EqualApprox(inf, b, 1) || EqualApproxRel(inf, b, 0, 1e-9) {
+			t.Errorf("want %v and %v to differ", inf, b)
+		}
+	}
+
+	nan, _ := FromSlice(1, 1, []float64{math.NaN()})
+	if EqualApprox(nan, nan, 1) {
+		t.Errorf("want NaN to never be equal")
+	}
+}
@@ -0,0 +1,108 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// WriteCSV writes the array to w as CSV, one record per row, converting each
+// value with format. Fields are separated by a comma by default; pass a
+// different rune as the optional comma argument to use another delimiter, such
+// as ';' or '\t'.
+func (a Array2D[T]) WriteCSV(w io.Writer, format func(T) string, comma ...rune) error {
+	cw := csv.NewWriter(w)
+	if len(comma) > 0 {
+		cw.Comma = comma[0]
+	}
+	record := make([]string, a.width)
+	for r := 0; r < a.height; r++ {
+		for c := range record {
+			record[c] = format(a.getUnchecked(r, c))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads CSV data from r into a row-major array with one row per record,
+// converting each field with parse. Fields are separated by a comma by
+// default; pass a different rune as the optional comma argument to use another
+// delimiter. Empty input yields an empty array.
+//
+// It returns an error wrapping ErrShape if the input is ragged, that is if any
+// record has a different number of fields than the first, and wraps any error
+// returned by parse with the position of the offending field.
+func ReadCSV[T any](r io.Reader, parse func(string) (T, error), comma ...rune) (Array2D[T], error) {
+	records, err := readRecords(r, comma)
+	if err != nil {
+		return Array2D[T]{}, err
+	}
+	if len(records) == 0 {
+		return New[T](0, 0), nil
+	}
+	return parseRecords(records, len(records[0]), 0, parse)
+}
+
+// ReadCSVHeader reads CSV data whose first record holds column names. It
+// returns the names as header and the remaining records, converted with parse,
+// as a row-major array whose height is the number of data records and whose
+// width is the number of columns in the header.
+//
+// It returns an error wrapping ErrShape if a data record has a different number
+// of fields than the header, and wraps any error returned by parse with the
+// position of the offending field. The optional comma argument selects the
+// field delimiter, as with ReadCSV.
+func ReadCSVHeader[T any](r io.Reader, parse func(string) (T, error), comma ...rune) (arr Array2D[T], header []string, err error) {
+	records, err := readRecords(r, comma)
+	if err != nil {
+		return Array2D[T]{}, nil, err
+	}
+	if len(records) == 0 {
+		return Array2D[T]{}, nil, fmt.Errorf("%w: csv input has no header record", ErrShape)
+	}
+	header = records[0]
+	arr, err = parseRecords(records[1:], len(header), 1, parse)
+	if err != nil {
+		return Array2D[T]{}, nil, err
+	}
+	return arr, header, nil
+}
+
+// readRecords reads all CSV records from r, using comma[0] as the delimiter
+// if given. Ragged records are accepted here and reported by parseRecords.
+func readRecords(r io.Reader, comma []rune) ([][]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if len(comma) > 0 {
+		cr.Comma = comma[0]
+	}
+	return cr.ReadAll()
+}
+
+// parseRecords converts CSV records into a row-major array of the given width.
+// firstLine is the 0-based index of records[0] in the input, used to report
+// positions in errors.
+func parseRecords[T any](records [][]string, width, firstLine int, parse func(string) (T, error)) (Array2D[T], error) {
+	arr := New[T](len(records), width)
+	for i, record := range records {
+		if len(record) != width {
+			return Array2D[T]{}, fmt.Errorf("%w: csv record %d has %d fields, want %d", ErrShape, firstLine+i, len(record), width)
+		}
+		row := arr.line(i)
+		for c, field := range record {
+			v, err := parse(field)
+			if err != nil {
+				return Array2D[T]{}, fmt.Errorf("array2d: csv record %d field %d: %w", firstLine+i, c, err)
+			}
+			row[c] = v
+		}
+	}
+	return arr, nil
+}
@@ -0,0 +1,100 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadCSVHeader(t *testing.T) {
+	input := "x,y,z\n1,2,3\n4,5,6\n"
+	arr, header, err := ReadCSVHeader(strings.NewReader(input), strconv.Atoi)
+	if err != nil {
+		t.Fatalf("ReadCSVHeader() returned an unexpected error: %v", err)
+	}
+	if want := []string{"x", "y", "z"}; !reflect.DeepEqual(header, want) {
+		t.Errorf("header: want %v, got %v", want, header)
+	}
+	want := "Array2d[int] 2x3 [[1 2 3] [4 5 6]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	t.Run("header only", func(t *testing.T) {
+		arr, header, err := ReadCSVHeader(strings.NewReader("a,b\n"), strconv.Atoi)
+		if err != nil {
+			t.Fatalf("ReadCSVHeader() returned an unexpected error: %v", err)
+		}
+		if len(header) != 2 || arr.Height() != 0 || arr.Width() != 2 {
+			t.Errorf("want 0x2 array and 2 header fields, got %dx%d and %v", arr.Height(), arr.Width(), header)
+		}
+	})
+
+	t.Run("row width mismatch", func(t *testing.T) {
+		_, _, err := ReadCSVHeader(strings.NewReader("a,b\n1,2\n3\n"), strconv.Atoi)
+		if !errors.Is(err, ErrShape) {
+			t.Errorf("want error to be ErrShape, but it was not. got: %v", err)
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		_, _, err := ReadCSVHeader(strings.NewReader("a,b\n1,x\n"), strconv.Atoi)
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("want wrapped strconv.ErrSyntax, got %v", err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "record 1 field 1") {
+			t.Errorf("want error to name the field position, got %q", err)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		_, _, err := ReadCSVHeader(strings.NewReader(""), strconv.Atoi)
+		if !errors.Is(err, ErrShape) {
+			t.Errorf("want error to be ErrShape, but it was not. got: %v", err)
+		}
+	})
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, true)
+
+	for _, comma := range []rune{',', ';', '\t'} {
+		t.Run(strconv.QuoteRune(comma), func(t *testing.T) {
+			var buf strings.Builder
+			if err := arr.WriteCSV(&buf, strconv.Itoa, comma); err != nil {
+				t.Fatalf("WriteCSV() returned an unexpected error: %v", err)
+			}
+			sep := string(comma)
+			if want := "1" + sep + "2" + sep + "3\n4" + sep + "5" + sep + "6\n"; buf.String() != want {
+				t.Errorf("WriteCSV: want %q, got %q", want, buf.String())
+			}
+
+			got, err := ReadCSV(strings.NewReader(buf.String()), strconv.Atoi, comma)
+			if err != nil {
+				t.Fatalf("ReadCSV() returned an unexpected error: %v", err)
+			}
+			if !Equal(arr, got) {
+				t.Errorf("round trip: want %v, got %v", arr, got)
+			}
+		})
+	}
+
+	t.Run("ragged", func(t *testing.T) {
+		_, err := ReadCSV(strings.NewReader("1,2\n3,4,5\n"), strconv.Atoi)
+		if !errors.Is(err, ErrShape) {
+			t.Errorf("want error to be ErrShape, but it was not. got: %v", err)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		got, err := ReadCSV(strings.NewReader(""), strconv.Atoi)
+		if err != nil || got.Height() != 0 || got.Width() != 0 {
+			t.Errorf("want empty array, got %v (err=%v)", got, err)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+)
+
+// binaryMagic identifies data produced by MarshalBinary.
+const binaryMagic = "A2D"
+
+// binaryVersion is the version of the MarshalBinary format. UnmarshalBinary
+// rejects data with any other version.
+const binaryVersion = 1
+
+// flagColMajor is set in the flags byte of the binary header for
+// column-major arrays.
+const flagColMajor = 1 << 0
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding starts with a
+// versioned header holding the height, width and memory layout, followed by
+// the elements in storage order encoded with encoding/gob, so T must be a type
+// that gob can encode.
+//
+// Because encoding/gob uses MarshalBinary and UnmarshalBinary when available,
+// arrays can also be sent directly with gob and net/rpc.
+func (a Array2D[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(binaryVersion)
+	var flags byte
+	if a.colMajor {
+		flags |= flagColMajor
+	}
+	buf.WriteByte(flags)
+	var dims [2 * binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(dims[:], uint64(a.height))
+	n += binary.PutUvarint(dims[n:], uint64(a.width))
+	buf.Write(dims[:n])
+	if err := gob.NewEncoder(&buf).Encode(a.Values()); err != nil {
+		return nil, fmt.Errorf("array2d: encoding elements: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing a with the
+// array encoded by MarshalBinary. It returns an error wrapping ErrFormat if
+// the data is malformed or was written by an unsupported version.
+func (a *Array2D[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+2 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return fmt.Errorf("%w: missing %s header", ErrFormat, binaryMagic)
+	}
+	data = data[len(binaryMagic):]
+	if data[0] != binaryVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrFormat, data[0])
+	}
+	colMajor := data[1]&flagColMajor != 0
+	r := bytes.NewReader(data[2:])
+	height, err := binary.ReadUvarint(r)
+	if err != nil {
+		return fmt.Errorf("%w: reading height: %v", ErrFormat, err)
+	}
+	width, err := binary.ReadUvarint(r)
+	if err != nil {
+		return fmt.Errorf("%w: reading width: %v", ErrFormat, err)
+	}
+	var slice []T
+	if err := gob.NewDecoder(r).Decode(&slice); err != nil {
+		return fmt.Errorf("%w: decoding elements: %v", ErrFormat, err)
+	}
+	if !dimsMatch(height, width, len(slice)) {
+		return fmt.Errorf("%w: %d elements do not fill a %dx%d array", ErrFormat, len(slice), height, width)
+	}
+	if slice == nil {
+		slice = []T{}
+	}
+	*a = newArray(int(height), int(width), slice, colMajor)
+	return nil
+}
+
+// dimsMatch reports whether an array of the decoded dimensions holds exactly n
+// elements, guarding against overflow in height*width.
+func dimsMatch(height, width uint64, n int) bool {
+	if height == 0 || width == 0 {
+		return n == 0 && height <= uint64(maxInt) && width <= uint64(maxInt)
+	}
+	return height <= uint64(n)/width && height*width == uint64(n)
+}
+
+// maxInt is the largest value of type int.
+const maxInt = int(^uint(0) >> 1)
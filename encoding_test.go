@@ -0,0 +1,89 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBinaryMarshal(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(2, 3, [][]string{{"a", "b", "c"}, {"d", "e", "f"}}, colMajor)
+			data, err := arr.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() returned an unexpected error: %v", err)
+			}
+			var got Array2D[string]
+			if err := got.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary() returned an unexpected error: %v", err)
+			}
+			if !Equal(arr, got) || got.colMajor != colMajor {
+				t.Errorf("want %v (colMajor=%v), got %v (colMajor=%v)", arr, colMajor, got, got.colMajor)
+			}
+		})
+	}
+
+	t.Run("view", func(t *testing.T) {
+		arr, _ := FromJagged(3, 3, [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+		view := arr.view(1, 1, 2, 2)
+		data, _ := view.MarshalBinary()
+		var got Array2D[int]
+		if err := got.UnmarshalBinary(data); err != nil || !Equal(view, got) {
+			t.Errorf("want %v, got %v (err=%v)", view, got, err)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		data, _ := New[int](0, 4).MarshalBinary()
+		var got Array2D[int]
+		if err := got.UnmarshalBinary(data); err != nil || got.Height() != 0 || got.Width() != 4 {
+			t.Errorf("want 0x4 array, got %v (err=%v)", got, err)
+		}
+	})
+
+	t.Run("gob", func(t *testing.T) {
+		type message struct {
+			Name string
+			Grid Array2D[float64]
+		}
+		grid, _ := FromSlice(2, 2, []float64{1.5, 2.5, 3.5, 4.5}, true)
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(message{Name: "grid", Grid: grid}); err != nil {
+			t.Fatalf("gob encode: %v", err)
+		}
+		var got message
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Fatalf("gob decode: %v", err)
+		}
+		if got.Name != "grid" || !Equal(grid, got.Grid) {
+			t.Errorf("want %v, got %v", grid, got.Grid)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		valid, _ := NewFilled(2, 2, 7).MarshalBinary()
+		wrongVersion := append([]byte(nil), valid...)
+		wrongVersion[3] = 99
+		wrongDims := append([]byte(nil), valid...)
+		wrongDims[5] = 3 // height 3 with only 4 elements
+
+		for name, data := range map[string][]byte{
+			"empty":         nil,
+			"bad magic":     []byte("XYZ\x01\x00\x02\x02"),
+			"wrong version": wrongVersion,
+			"wrong dims":    wrongDims,
+			"truncated":     valid[:len(valid)-2],
+		} {
+			var got Array2D[int]
+			if err := got.UnmarshalBinary(data); !errors.Is(err, ErrFormat) {
+				t.Errorf("%s: want ErrFormat, got %v", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,49 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+// ConnectedComponents labels each connected region of non-background cells in a.
+// Two non-background cells belong to the same region when they touch
+// horizontally or vertically (4-connectivity), or also diagonally when
+// diagonal is true (8-connectivity).
+//
+// The returned labels array has the same dimensions and memory layout as a.
+// Background cells are labeled 0 and regions are numbered from 1 in the order
+// their first cell is found in a logical row-major scan. count is the number of
+// regions found.
+func ConnectedComponents[T comparable](a Array2D[T], background T, diagonal bool) (labels Array2D[int], count int) {
+	labels = New[int](a.height, a.width, a.colMajor)
+	offsets := [][2]int{{-1, 0}, {0, -1}, {0, 1}, {1, 0}}
+	if diagonal {
+		offsets = append(offsets, [2]int{-1, -1}, [2]int{-1, 1}, [2]int{1, -1}, [2]int{1, 1})
+	}
+
+	var stack [][2]int
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if a.getUnchecked(r, c) == background || labels.getUnchecked(r, c) != 0 {
+				continue
+			}
+			count++
+			labels.setUnchecked(r, c, count)
+			stack = append(stack[:0], [2]int{r, c})
+			for len(stack) > 0 {
+				cell := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				for _, off := range offsets {
+					nr, nc := cell[0]+off[0], cell[1]+off[1]
+					if nr < 0 || nr >= a.height || nc < 0 || nc >= a.width {
+						continue
+					}
+					if labels.getUnchecked(nr, nc) != 0 || a.getUnchecked(nr, nc) == background {
+						continue
+					}
+					labels.setUnchecked(nr, nc, count)
+					stack = append(stack, [2]int{nr, nc})
+				}
+			}
+		}
+	}
+	return labels, count
+}
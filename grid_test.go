@@ -0,0 +1,46 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import "testing"
+
+func TestConnectedComponents(t *testing.T) {
+	arr, _ := FromJagged(4, 5, [][]int{
+		{1, 1, 0, 0, 0},
+		{1, 0, 0, 2, 2},
+		{0, 0, 0, 0, 3},
+		{0, 1, 0, 0, 0},
+	})
+
+	t.Run("4-connectivity", func(t *testing.T) {
+		labels, count := ConnectedComponents(arr, 0, false)
+		if count != 3 {
+			t.Fatalf("want 3 components, got %d", count)
+		}
+		want := "Array2d[int] 4x5 [[1 1 0 0 0] [1 0 0 2 2] [0 0 0 0 2] [0 3 0 0 0]]"
+		if got := labels.String(); got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("8-connectivity", func(t *testing.T) {
+		diag, _ := FromJagged(4, 3, [][]int{
+			{1, 0, 0},
+			{0, 1, 0},
+			{0, 0, 0},
+			{5, 0, 0},
+		}, true)
+		labels, count := ConnectedComponents(diag, 0, true)
+		if count != 2 {
+			t.Fatalf("want 2 components, got %d", count)
+		}
+		want := "Array2d[int] 4x3 [[1 0 0] [0 1 0] [0 0 0] [2 0 0]]"
+		if got := labels.String(); got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+		if _, count := ConnectedComponents(diag, 0, false); count != 3 {
+			t.Errorf("want 3 components without diagonals, got %d", count)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"image"
+	"image/color"
+)
+
+// ToImage returns an image.Image that is Width() pixels wide and Height()
+// pixels tall, in which the pixel at (x, y) is colorMap applied to the cell at
+// row y and column x. The result can be passed directly to encoders such as
+// image/png.
+//
+// The image is a lazy view: colorMap is called each time a pixel is read and
+// changes to the array are reflected in the image. Pixels are color.RGBA64
+// values, the image's color model, whatever type colorMap returns.
+func ToImage[T Number](a Array2D[T], colorMap func(T) color.Color) image.Image {
+	return arrayImage[T]{arr: a, colorMap: colorMap}
+}
+
+// FromImage creates a row-major array with the dimensions of img's bounds, in
+// which the cell at row y and column x holds convert applied to the pixel at
+// (Bounds().Min.X+x, Bounds().Min.Y+y). The top-left pixel of the image is
+// therefore always at (0, 0), even when the bounds do not start at the origin.
+func FromImage[T any](img image.Image, convert func(color.Color) T) Array2D[T] {
+	bounds := img.Bounds()
+	arr := New[T](bounds.Dy(), bounds.Dx())
+	for y := 0; y < arr.height; y++ {
+		row := arr.line(y)
+		for x := range row {
+			row[x] = convert(img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return arr
+}
+
+// arrayImage adapts an Array2D to the image.Image interface.
+type arrayImage[T any] struct {
+	arr      Array2D[T]
+	colorMap func(T) color.Color
+}
+
+func (img arrayImage[T]) ColorModel() color.Model {
+	return color.RGBA64Model
+}
+
+func (img arrayImage[T]) Bounds() image.Rectangle {
+	return image.Rect(0, 0, img.arr.width, img.arr.height)
+}
+
+func (img arrayImage[T]) At(x, y int) color.Color {
+	v, ok := img.arr.Get(y, x)
+	if !ok {
+		return color.RGBA64{}
+	}
+	// At must return colors in the image's color model, whatever type
+	// colorMap returns.
+	return color.RGBA64Model.Convert(img.colorMap(v))
+}
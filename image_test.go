@@ -0,0 +1,54 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestToImage(t *testing.T) {
+	arr, _ := FromSlice(2, 3, []float64{0, 0.5, 1, 1, 0.5, 0})
+	img := ToImage(arr, func(v float64) color.Color {
+		return color.Gray{Y: uint8(v * 255)}
+	})
+
+	if want := image.Rect(0, 0, 3, 2); img.Bounds() != want {
+		t.Errorf("Bounds(): want %v, got %v", want, img.Bounds())
+	}
+	for _, tc := range []struct {
+		x, y int
+		want uint8
+	}{
+		{0, 0, 0},
+		{1, 0, 127},
+		{2, 0, 255},
+		{0, 1, 255},
+	} {
+		if got := color.GrayModel.Convert(img.At(tc.x, tc.y)).(color.Gray).Y; got != tc.want {
+			t.Errorf("At(%d, %d): want gray %d, got %d", tc.x, tc.y, tc.want, got)
+		}
+	}
+	if c, ok := img.At(1, 1).(color.RGBA64); !ok || img.ColorModel().Convert(c) != c {
+		t.Errorf("At(): want a color in the image's color model, got %T", img.At(1, 1))
+	}
+	if _, _, _, a := img.At(3, 0).RGBA(); a != 0 {
+		t.Errorf("At() outside bounds: want transparent, got alpha %d", a)
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	img := image.NewGray(image.Rect(10, 20, 13, 22))
+	img.SetGray(10, 20, color.Gray{Y: 1})
+	img.SetGray(12, 21, color.Gray{Y: 200})
+
+	arr := FromImage(img, func(c color.Color) uint8 {
+		return color.GrayModel.Convert(c).(color.Gray).Y
+	})
+	want := "Array2d[uint8] 2x3 [[1 0 0] [0 0 200]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,268 @@
+//go:build go1.23
+
+package array2d
+
+import "iter"
+
+// Diagonals returns an iterator over the anti-diagonals of the array, starting
+// at the top-left corner and ending at the bottom-right corner.
+//
+// Each anti-diagonal holds the cells whose row+col is constant, ordered from
+// top-right toward bottom-left. An m x n array has m+n-1 anti-diagonals.
+// Each yielded slice is a fresh copy that the caller may keep or modify.
+// This order suits wavefront algorithms such as edit distance, where each
+// cell depends on the previous anti-diagonals.
+func (a Array2D[T]) Diagonals() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if a.height == 0 || a.width == 0 {
+			return
+		}
+		for d := 0; d < a.height+a.width-1; d++ {
+			rowStart := max(0, d-a.width+1)
+			rowEnd := min(d, a.height-1)
+			diag := make([]T, 0, rowEnd-rowStart+1)
+			for r := rowStart; r <= rowEnd; r++ {
+				diag = append(diag, a.getUnchecked(r, d-r))
+			}
+			if !yield(diag) {
+				return
+			}
+		}
+	}
+}
+
+// OffsetDiagonals returns an iterator over the diagonals of the array that run
+// from top-left to bottom-right, yielding each diagonal's offset k with a copy
+// of its cells as returned by DiagonalOffset. The offsets run from
+// -(Height()-1), the bottom-left corner, to Width()-1, the top-right corner,
+// so an m x n array yields m+n-1 diagonals.
+//
+// Use Diagonals for the anti-diagonals.
+func (a Array2D[T]) OffsetDiagonals() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		if a.height == 0 || a.width == 0 {
+			return
+		}
+		for k := 1 - a.height; k < a.width; k++ {
+			diag, _ := a.DiagonalOffset(k)
+			if !yield(k, diag) {
+				return
+			}
+		}
+	}
+}
+
+// Chunks returns an iterator over the non-overlapping chunkH x chunkW tiles of
+// the array, in logical row-major order of their top-left corners. Tiles along
+// the bottom and right edges are smaller when the dimensions are not multiples
+// of the chunk size. Nothing is yielded if either chunk dimension is not
+// positive.
+//
+// Each tile is yielded with its top-left coordinate as a view that shares
+// storage with the array, so writes through a tile affect the array.
+func (a Array2D[T]) Chunks(chunkH, chunkW int) iter.Seq2[[2]int, Array2D[T]] {
+	return func(yield func([2]int, Array2D[T]) bool) {
+		if chunkH <= 0 || chunkW <= 0 {
+			return
+		}
+		for r := 0; r < a.height; r += chunkH {
+			for c := 0; c < a.width; c += chunkW {
+				tile := a.view(r, c, min(chunkH, a.height-r), min(chunkW, a.width-c))
+				if !yield([2]int{r, c}, tile) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Blocks returns an iterator over the non-overlapping blockH x blockW tiles of
+// the array. It is the same as Chunks, under the name used by cache-blocked
+// algorithms: each tile is yielded with its top-left coordinate as a view that
+// shares storage with the array, and edge tiles are smaller when the
+// dimensions are not multiples of the block size.
+func (a Array2D[T]) Blocks(blockH, blockW int) iter.Seq2[[2]int, Array2D[T]] {
+	return a.Chunks(blockH, blockW)
+}
+
+// Windows returns an iterator over the h x w windows of the array, in logical
+// row-major order of their top-left corners. The windows slide by one cell by
+// default; stride sets the step between windows, either as a single value for
+// both directions or as separate row and column steps. Only windows that fit
+// entirely inside the array are yielded, and nothing is yielded if h, w or a
+// stride is not positive.
+//
+// Each window is yielded with its top-left coordinate as a view that shares
+// storage with the array, so writes through a window affect the array. Copy a
+// window to keep it beyond the iteration step.
+func (a Array2D[T]) Windows(h, w int, stride ...int) iter.Seq2[[2]int, Array2D[T]] {
+	strideH, strideW := 1, 1
+	if len(stride) > 0 {
+		strideH, strideW = stride[0], stride[0]
+	}
+	if len(stride) > 1 {
+		strideW = stride[1]
+	}
+	return func(yield func([2]int, Array2D[T]) bool) {
+		if h <= 0 || w <= 0 || strideH <= 0 || strideW <= 0 {
+			return
+		}
+		for r := 0; r+h <= a.height; r += strideH {
+			for c := 0; c+w <= a.width; c += strideW {
+				if !yield([2]int{r, c}, a.view(r, c, h, w)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Spiral returns an iterator over the cells of the array in clockwise spiral
+// order, yielding each cell's coordinate and value. The spiral starts at the
+// top-left corner, runs along the top row and down the right column, and
+// winds inward until every cell has been visited once.
+func (a Array2D[T]) Spiral() iter.Seq2[[2]int, T] {
+	return func(yield func([2]int, T) bool) {
+		for i := 0; i <= a.height-1-i && i <= a.width-1-i; i++ {
+			if !a.ring(i, i, a.height-1-i, a.width-1-i, yield) {
+				return
+			}
+		}
+	}
+}
+
+// Perimeter returns an iterator over the border cells of the array in
+// clockwise order starting at the top-left corner, yielding each cell's
+// coordinate and value. Every border cell is visited once, so a single row or
+// column is traversed only once. It is the first ring of Spiral.
+func (a Array2D[T]) Perimeter() iter.Seq2[[2]int, T] {
+	return func(yield func([2]int, T) bool) {
+		if a.height > 0 && a.width > 0 {
+			a.ring(0, 0, a.height-1, a.width-1, yield)
+		}
+	}
+}
+
+// ring yields the cells on the border of the region with the given inclusive
+// corners in clockwise order, and reports whether yield asked to continue.
+func (a Array2D[T]) ring(top, left, bottom, right int, yield func([2]int, T) bool) bool {
+	visit := func(r, c int) bool {
+		return yield([2]int{r, c}, a.getUnchecked(r, c))
+	}
+	for c := left; c <= right; c++ {
+		if !visit(top, c) {
+			return false
+		}
+	}
+	for r := top + 1; r <= bottom; r++ {
+		if !visit(r, right) {
+			return false
+		}
+	}
+	if top < bottom {
+		for c := right - 1; c >= left; c-- {
+			if !visit(bottom, c) {
+				return false
+			}
+		}
+	}
+	if left < right {
+		for r := bottom - 1; r > top; r-- {
+			if !visit(r, left) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// neighborOffsets4 and neighborOffsets8 list the offsets of the von Neumann
+// and Moore neighborhoods in logical row-major order.
+var (
+	neighborOffsets4 = [][2]int{{-1, 0}, {0, -1}, {0, 1}, {1, 0}}
+	neighborOffsets8 = [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+)
+
+// Neighbors4 returns an iterator over the von Neumann neighbors of (row, col):
+// the cells above, to the left, to the right and below, in that order. It
+// yields each neighbor's coordinate and value, skipping neighbors outside the
+// array, and nothing if (row, col) itself is out of bounds.
+//
+// If wrap is true, the array is treated as a torus: neighbors past an edge
+// wrap around to the opposite edge, so every cell has four neighbors. In
+// arrays with fewer than three rows or columns, wrapped neighbors may then
+// repeat or be the cell itself.
+func (a Array2D[T]) Neighbors4(row, col int, wrap ...bool) iter.Seq2[[2]int, T] {
+	return a.neighbors(row, col, neighborOffsets4, len(wrap) > 0 && wrap[0])
+}
+
+// Neighbors8 is like Neighbors4 but iterates over the Moore neighborhood: the
+// eight cells surrounding (row, col), including the diagonals, in logical
+// row-major order.
+func (a Array2D[T]) Neighbors8(row, col int, wrap ...bool) iter.Seq2[[2]int, T] {
+	return a.neighbors(row, col, neighborOffsets8, len(wrap) > 0 && wrap[0])
+}
+
+// neighbors yields the cells at the given offsets from (row, col).
+func (a Array2D[T]) neighbors(row, col int, offsets [][2]int, wrap bool) iter.Seq2[[2]int, T] {
+	return func(yield func([2]int, T) bool) {
+		if row < 0 || row >= a.height || col < 0 || col >= a.width {
+			return
+		}
+		for _, off := range offsets {
+			r, c := row+off[0], col+off[1]
+			if wrap {
+				r, c = (r+a.height)%a.height, (c+a.width)%a.width
+			} else if r < 0 || r >= a.height || c < 0 || c >= a.width {
+				continue
+			}
+			if !yield([2]int{r, c}, a.getUnchecked(r, c)) {
+				return
+			}
+		}
+	}
+}
+
+// Seq adapts the iterator for use with range-over-func. It advances the
+// iterator with Next and yields each row's index and a freshly allocated copy
+// of its values, so yielded slices never alias the array or each other.
+// For example:
+//
+//	for i, row := range arr.Rows().Seq() {
+//		// use i and row
+//	}
+func (r *Rows[T]) Seq() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for r.Next() {
+			row := make([]T, r.arr.width)
+			if err := r.Scan(&row); err != nil {
+				return
+			}
+			if !yield(r.row, row) {
+				return
+			}
+		}
+	}
+}
+
+// Seq adapts the iterator for use with range-over-func. It advances the
+// iterator with Next and yields each column's index and a freshly allocated
+// copy of its values, so yielded slices never alias the array or each other.
+// For example:
+//
+//	for j, col := range arr.Cols().Seq() {
+//		// use j and col
+//	}
+func (c *Cols[T]) Seq() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for c.Next() {
+			col := make([]T, c.arr.height)
+			if err := c.Scan(&col); err != nil {
+				return
+			}
+			if !yield(c.col, col) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,245 @@
+//go:build go1.23
+
+package array2d
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestArray2D_Diagonals(t *testing.T) {
+	arr, _ := FromSlice(3, 3, []int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	var got [][]int
+	for diag := range arr.Diagonals() {
+		got = append(got, diag)
+	}
+	want := [][]int{{1}, {2, 4}, {3, 5, 7}, {6, 8}, {9}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagonals(): want %v, got %v", want, got)
+	}
+}
+
+func TestArray2D_OffsetDiagonals(t *testing.T) {
+	arr, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+
+	var offsets []int
+	var got [][]int
+	for k, diag := range arr.OffsetDiagonals() {
+		offsets = append(offsets, k)
+		got = append(got, diag)
+	}
+	if want := []int{-1, 0, 1, 2}; !reflect.DeepEqual(offsets, want) {
+		t.Errorf("OffsetDiagonals(): want offsets %v, got %v", want, offsets)
+	}
+	if want := [][]int{{4}, {1, 5}, {2, 6}, {3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OffsetDiagonals(): want %v, got %v", want, got)
+	}
+
+	for range New[int](0, 3).OffsetDiagonals() {
+		t.Errorf("want no diagonals for an empty array")
+	}
+}
+
+func TestArray2D_Chunks(t *testing.T) {
+	arr := New[int](5, 5)
+	for i := 0; i < arr.Height(); i++ {
+		for j := 0; j < arr.Width(); j++ {
+			_ = arr.Set(i, j, i*10+j)
+		}
+	}
+
+	var corners [][2]int
+	var sizes [][2]int
+	for corner, tile := range arr.Chunks(2, 2) {
+		corners = append(corners, corner)
+		sizes = append(sizes, [2]int{tile.Height(), tile.Width()})
+		if got, _ := tile.Get(0, 0); got != corner[0]*10+corner[1] {
+			t.Errorf("tile at %v: want top-left value %d, got %d", corner, corner[0]*10+corner[1], got)
+		}
+	}
+
+	wantCorners := [][2]int{{0, 0}, {0, 2}, {0, 4}, {2, 0}, {2, 2}, {2, 4}, {4, 0}, {4, 2}, {4, 4}}
+	if !reflect.DeepEqual(corners, wantCorners) {
+		t.Errorf("want corners %v, got %v", wantCorners, corners)
+	}
+	wantSizes := [][2]int{{2, 2}, {2, 2}, {2, 1}, {2, 2}, {2, 2}, {2, 1}, {1, 2}, {1, 2}, {1, 1}}
+	if !reflect.DeepEqual(sizes, wantSizes) {
+		t.Errorf("want sizes %v, got %v", wantSizes, sizes)
+	}
+
+	for _, tile := range arr.Chunks(2, 2) {
+		tile.SetAll(-1)
+		break
+	}
+	if got, _ := arr.Get(1, 1); got != -1 {
+		t.Errorf("write through a tile not visible in the array, got %d", got)
+	}
+}
+
+func TestArray2D_Blocks(t *testing.T) {
+	arr := New[int](3, 5)
+	var got [][2]int
+	for corner, tile := range arr.Blocks(2, 3) {
+		got = append(got, corner, [2]int{tile.Height(), tile.Width()})
+	}
+	want := [][2]int{{0, 0}, {2, 3}, {0, 3}, {2, 2}, {2, 0}, {1, 3}, {2, 3}, {1, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Blocks(): want corners and sizes %v, got %v", want, got)
+	}
+}
+
+func TestArray2D_Windows(t *testing.T) {
+	arr, _ := FromSlice(3, 4, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, true)
+
+	var corners [][2]int
+	var sums []int
+	for corner, win := range arr.Windows(2, 2) {
+		corners = append(corners, corner)
+		sums = append(sums, Sum(win))
+	}
+	wantCorners := [][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}}
+	if !reflect.DeepEqual(corners, wantCorners) {
+		t.Errorf("want corners %v, got %v", wantCorners, corners)
+	}
+	if want := []int{12, 24, 36, 16, 28, 40}; !reflect.DeepEqual(sums, want) {
+		t.Errorf("want window sums %v, got %v", want, sums)
+	}
+
+	corners = nil
+	for corner := range arr.Windows(2, 2, 1, 2) {
+		corners = append(corners, corner)
+	}
+	if want := [][2]int{{0, 0}, {0, 2}, {1, 0}, {1, 2}}; !reflect.DeepEqual(corners, want) {
+		t.Errorf("Windows with stride (1, 2): want corners %v, got %v", want, corners)
+	}
+
+	for _, args := range [][]int{{4, 1}, {1, 5}, {0, 1}, {1, 1, 0}} {
+		for range arr.Windows(args[0], args[1], args[2:]...) {
+			t.Errorf("Windows%v: want no windows", args)
+			break
+		}
+	}
+}
+
+func TestArray2D_SpiralPerimeter(t *testing.T) {
+	tests := []struct {
+		height, width int
+		spiral        []int
+		perimeter     []int
+	}{
+		{3, 4, []int{0, 1, 2, 3, 7, 11, 10, 9, 8, 4, 5, 6}, []int{0, 1, 2, 3, 7, 11, 10, 9, 8, 4}},
+		{4, 3, []int{0, 1, 2, 5, 8, 11, 10, 9, 6, 3, 4, 7}, []int{0, 1, 2, 5, 8, 11, 10, 9, 6, 3}},
+		{1, 3, []int{0, 1, 2}, []int{0, 1, 2}},
+		{3, 1, []int{0, 1, 2}, []int{0, 1, 2}},
+		{0, 3, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%dx%d", tt.height, tt.width), func(t *testing.T) {
+			slice := make([]int, tt.height*tt.width)
+			for i := range slice {
+				slice[i] = i
+			}
+			arr, _ := FromSlice(tt.height, tt.width, slice)
+
+			var spiral []int
+			for at, v := range arr.Spiral() {
+				if v != at[0]*tt.width+at[1] {
+					t.Errorf("Spiral(): value %d does not match coordinate %v", v, at)
+				}
+				spiral = append(spiral, v)
+			}
+			if !reflect.DeepEqual(spiral, tt.spiral) {
+				t.Errorf("Spiral(): want %v, got %v", tt.spiral, spiral)
+			}
+			var perimeter []int
+			for _, v := range arr.Perimeter() {
+				perimeter = append(perimeter, v)
+			}
+			if !reflect.DeepEqual(perimeter, tt.perimeter) {
+				t.Errorf("Perimeter(): want %v, got %v", tt.perimeter, perimeter)
+			}
+		})
+	}
+
+	arr := New[int](3, 3)
+	n := 0
+	for range arr.Spiral() {
+		if n++; n == 4 {
+			break
+		}
+	}
+	if n != 4 {
+		t.Errorf("Spiral(): want iteration to stop after 4 cells, got %d", n)
+	}
+}
+
+func TestArray2D_Neighbors(t *testing.T) {
+	arr, _ := FromSlice(3, 3, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, true) // [[1 4 7] [2 5 8] [3 6 9]]
+	collect := func(seq func(func([2]int, int) bool)) (coords [][2]int, values []int) {
+		for at, v := range seq {
+			coords = append(coords, at)
+			values = append(values, v)
+		}
+		return coords, values
+	}
+
+	tests := []struct {
+		name     string
+		seq      func(func([2]int, int) bool)
+		wantAt   [][2]int
+		wantVals []int
+	}{
+		{"Neighbors4 center", arr.Neighbors4(1, 1), [][2]int{{0, 1}, {1, 0}, {1, 2}, {2, 1}}, []int{4, 2, 8, 6}},
+		{"Neighbors4 corner", arr.Neighbors4(0, 0), [][2]int{{0, 1}, {1, 0}}, []int{4, 2}},
+		{"Neighbors4 wrap", arr.Neighbors4(0, 0, true), [][2]int{{2, 0}, {0, 2}, {0, 1}, {1, 0}}, []int{3, 7, 4, 2}},
+		{"Neighbors8 edge", arr.Neighbors8(0, 1), [][2]int{{0, 0}, {0, 2}, {1, 0}, {1, 1}, {1, 2}}, []int{1, 7, 2, 5, 8}},
+		{"Neighbors8 wrap", arr.Neighbors8(2, 2, true), [][2]int{{1, 1}, {1, 2}, {1, 0}, {2, 1}, {2, 0}, {0, 1}, {0, 2}, {0, 0}}, []int{5, 8, 2, 6, 3, 4, 7, 1}},
+		{"out of bounds", arr.Neighbors8(3, 0), nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, vals := collect(tt.seq)
+			if !reflect.DeepEqual(at, tt.wantAt) || !reflect.DeepEqual(vals, tt.wantVals) {
+				t.Errorf("want %v %v, got %v %v", tt.wantAt, tt.wantVals, at, vals)
+			}
+		})
+	}
+}
+
+func TestRowsColsSeq(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, colMajor)
+
+		var rowIdx []int
+		var rows [][]int
+		for i, row := range arr.Rows().Seq() {
+			rowIdx = append(rowIdx, i)
+			rows = append(rows, row)
+		}
+		if want := []int{0, 1}; !reflect.DeepEqual(rowIdx, want) {
+			t.Errorf("row indices: want %v, got %v", want, rowIdx)
+		}
+		if want := [][]int{{1, 2, 3}, {4, 5, 6}}; !reflect.DeepEqual(rows, want) {
+			t.Errorf("rows: want %v, got %v", want, rows)
+		}
+		rows[0][0] = 99
+		if got, _ := arr.Get(0, 0); got != 1 {
+			t.Errorf("colMajor=%v: yielded row aliases the array", colMajor)
+		}
+
+		var colIdx []int
+		var cols [][]int
+		for i, col := range arr.ColsReverse().Seq() {
+			colIdx = append(colIdx, i)
+			cols = append(cols, col)
+		}
+		if want := []int{2, 1, 0}; !reflect.DeepEqual(colIdx, want) {
+			t.Errorf("col indices: want %v, got %v", want, colIdx)
+		}
+		if want := [][]int{{3, 6}, {2, 5}, {1, 4}}; !reflect.DeepEqual(cols, want) {
+			t.Errorf("cols: want %v, got %v", want, cols)
+		}
+	}
+}
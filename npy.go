@@ -0,0 +1,201 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// npyMagic starts every .npy file.
+const npyMagic = "\x93NUMPY"
+
+// npyMaxHeaderLen bounds the header length LoadNPY accepts. Headers written by
+// NumPy for two-dimensional arrays are a few hundred bytes at most.
+const npyMaxHeaderLen = 1 << 16
+
+var (
+	npyDescrRe   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortranRe = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShapeRe   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// SaveNPY writes the array to w in the NumPy .npy format, so that it can be
+// loaded with numpy.load. Column-major arrays are written with fortran_order
+// set, which lets the data be written in storage order without reordering.
+//
+// T must be a boolean, integer or floating-point type of fixed size (int and
+// uint are not supported, as their size depends on the platform).
+func (a Array2D[T]) SaveNPY(w io.Writer) error {
+	descr, err := npyDescr[T]()
+	if err != nil {
+		return err
+	}
+	fortran := "False"
+	if a.colMajor {
+		fortran = "True"
+	}
+	dict := fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': (%d, %d), }", descr, fortran, a.height, a.width)
+
+	// The header, including the magic, version and length fields, is padded
+	// with spaces and a trailing newline to a multiple of 64 bytes. Version
+	// 2.0 is only needed when the header does not fit a 16-bit length.
+	version, lenSize := byte(1), 2
+	headerLen := npyHeaderLen(len(dict), lenSize)
+	if headerLen > 0xffff {
+		version, lenSize = 2, 4
+		headerLen = npyHeaderLen(len(dict), lenSize)
+	}
+	padding := headerLen - len(dict) - 1
+
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.WriteByte(version)
+	buf.WriteByte(0)
+	if lenSize == 2 {
+		binary.Write(&buf, binary.LittleEndian, uint16(headerLen))
+	} else {
+		binary.Write(&buf, binary.LittleEndian, uint32(headerLen))
+	}
+	buf.WriteString(dict)
+	buf.WriteString(strings.Repeat(" ", padding))
+	buf.WriteByte('\n')
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	count, length := a.lines()
+	if length == 0 {
+		return nil
+	}
+	for i := 0; i < count; i++ {
+		if err := binary.Write(w, binary.LittleEndian, a.line(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadNPY reads a two-dimensional array in the NumPy .npy format (versions
+// 1.0, 2.0 and 3.0) from r. Arrays stored with fortran_order set are returned
+// as column-major arrays and all others as row-major arrays; in both cases the
+// data is read without reordering. Both byte orders are supported.
+//
+// The dtype of the file must match T exactly, for example '<f8' for float64.
+// It returns an error wrapping ErrFormat if the header is malformed, the dtype
+// does not match or the shape is too large to address, and ErrShape if the
+// array is not two-dimensional. As with ReadFrom, storage is allocated as the
+// data arrives.
+func LoadNPY[T any](r io.Reader) (Array2D[T], error) {
+	want, err := npyDescr[T]()
+	if err != nil {
+		return Array2D[T]{}, err
+	}
+	var preamble [len(npyMagic) + 2]byte
+	if _, err := io.ReadFull(r, preamble[:]); err != nil {
+		return Array2D[T]{}, fmt.Errorf("%w: reading npy preamble: %v", ErrFormat, err)
+	}
+	if string(preamble[:len(npyMagic)]) != npyMagic {
+		return Array2D[T]{}, fmt.Errorf("%w: missing npy magic string", ErrFormat)
+	}
+	var headerLen uint32
+	switch major := preamble[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		err = binary.Read(r, binary.LittleEndian, &n)
+		headerLen = uint32(n)
+	case 2, 3:
+		err = binary.Read(r, binary.LittleEndian, &headerLen)
+	default:
+		return Array2D[T]{}, fmt.Errorf("%w: unsupported npy version %d", ErrFormat, major)
+	}
+	if err != nil {
+		return Array2D[T]{}, fmt.Errorf("%w: reading npy header length: %v", ErrFormat, err)
+	}
+	if headerLen > npyMaxHeaderLen {
+		return Array2D[T]{}, fmt.Errorf("%w: npy header length %d exceeds %d", ErrFormat, headerLen, npyMaxHeaderLen)
+	}
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Array2D[T]{}, fmt.Errorf("%w: reading npy header: %v", ErrFormat, err)
+	}
+
+	descr := npyDescrRe.FindSubmatch(header)
+	fortran := npyFortranRe.FindSubmatch(header)
+	shape := npyShapeRe.FindSubmatch(header)
+	if descr == nil || fortran == nil || shape == nil {
+		return Array2D[T]{}, fmt.Errorf("%w: npy header %q lacks descr, fortran_order or shape", ErrFormat, header)
+	}
+	got := string(descr[1])
+	var order binary.ByteOrder = binary.LittleEndian
+	if len(got) > 0 && got[0] == '>' {
+		order = binary.BigEndian
+	}
+	if len(got) == 0 || got[1:] != want[1:] {
+		return Array2D[T]{}, fmt.Errorf("%w: npy dtype %q does not match %T", ErrFormat, got, *new(T))
+	}
+
+	var dims []int
+	for _, field := range strings.Split(string(shape[1]), ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return Array2D[T]{}, fmt.Errorf("%w: invalid npy shape (%s)", ErrFormat, shape[1])
+		}
+		dims = append(dims, n)
+	}
+	if len(dims) != 2 {
+		return Array2D[T]{}, fmt.Errorf("%w: npy array has %d dimensions, want 2", ErrShape, len(dims))
+	}
+	size := binary.Size(*new(T))
+	if err := checkDataSize(uint64(dims[0]), uint64(dims[1]), size); err != nil {
+		return Array2D[T]{}, err
+	}
+
+	slice, err := readElements[T](r, order, dims[0]*dims[1], size)
+	if err != nil {
+		return Array2D[T]{}, fmt.Errorf("array2d: reading npy data: %w", err)
+	}
+	return newArray(dims[0], dims[1], slice, string(fortran[1]) == "True"), nil
+}
+
+// npyHeaderLen returns the padded length of a header holding a dictionary of
+// dictLen bytes, for a length field of lenSize bytes.
+func npyHeaderLen(dictLen, lenSize int) int {
+	prefix := len(npyMagic) + 2 + lenSize
+	return (prefix+dictLen+1+63)/64*64 - prefix
+}
+
+// npyDescr returns the little-endian NumPy dtype descriptor for T.
+func npyDescr[T any]() (string, error) {
+	var zero T
+	typ := reflect.TypeOf(zero)
+	if typ != nil {
+		switch typ.Kind() {
+		case reflect.Bool:
+			return "|b1", nil
+		case reflect.Int8:
+			return "|i1", nil
+		case reflect.Uint8:
+			return "|u1", nil
+		case reflect.Int16, reflect.Int32, reflect.Int64:
+			return fmt.Sprintf("<i%d", typ.Size()), nil
+		case reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return fmt.Sprintf("<u%d", typ.Size()), nil
+		case reflect.Float32, reflect.Float64:
+			return fmt.Sprintf("<f%d", typ.Size()), nil
+		case reflect.Complex64, reflect.Complex128:
+			return fmt.Sprintf("<c%d", typ.Size()), nil
+		}
+	}
+	return "", fmt.Errorf("array2d: element type %v has no fixed-size npy dtype", typ)
+}
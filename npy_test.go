@@ -0,0 +1,100 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNPY(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("colMajor=%v", colMajor), func(t *testing.T) {
+			arr, _ := FromJagged(2, 3, [][]float64{{1.5, 2, 3}, {4, 5, -6}}, colMajor)
+			var buf bytes.Buffer
+			if err := arr.SaveNPY(&buf); err != nil {
+				t.Fatalf("SaveNPY() returned an unexpected error: %v", err)
+			}
+
+			data := buf.Bytes()
+			headerLen := int(binary.LittleEndian.Uint16(data[8:10]))
+			if (10+headerLen)%64 != 0 || data[9+headerLen] != '\n' {
+				t.Errorf("header of length %d is not padded to a multiple of 64", headerLen)
+			}
+			wantDict := fmt.Sprintf("{'descr': '<f8', 'fortran_order': %s, 'shape': (2, 3), }", map[bool]string{false: "False", true: "True"}[colMajor])
+			if !strings.HasPrefix(string(data[10:]), wantDict) {
+				t.Errorf("want header %q, got %q", wantDict, data[10:10+headerLen])
+			}
+
+			got, err := LoadNPY[float64](&buf)
+			if err != nil {
+				t.Fatalf("LoadNPY() returned an unexpected error: %v", err)
+			}
+			if !Equal(arr, got) || got.colMajor != colMajor {
+				t.Errorf("want %v, got %v", arr, got)
+			}
+		})
+	}
+
+	t.Run("big-endian", func(t *testing.T) {
+		dict := "{'descr': '>i2', 'fortran_order': False, 'shape': (1, 2), }"
+		var buf bytes.Buffer
+		buf.WriteString("\x93NUMPY\x01\x00")
+		binary.Write(&buf, binary.LittleEndian, uint16(len(dict)+1))
+		buf.WriteString(dict + "\n")
+		buf.Write([]byte{0x01, 0x02, 0xff, 0xfe})
+
+		got, err := LoadNPY[int16](&buf)
+		if err != nil {
+			t.Fatalf("LoadNPY() returned an unexpected error: %v", err)
+		}
+		want := "Array2d[int16] 1x2 [[258 -2]]"
+		if got.String() != want {
+			t.Errorf("want %q, got %q", want, got.String())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if err := New[int](1, 1).SaveNPY(&bytes.Buffer{}); err == nil {
+			t.Errorf("want error for platform-sized int")
+		}
+
+		var buf bytes.Buffer
+		NewFilled[int32](2, 2, 7).SaveNPY(&buf)
+		if _, err := LoadNPY[int64](bytes.NewReader(buf.Bytes())); !errors.Is(err, ErrFormat) {
+			t.Errorf("dtype mismatch: want ErrFormat, got %v", err)
+		}
+		oneDim := bytes.Replace(buf.Bytes(), []byte("(2, 2)"), []byte("(4,)  "), 1)
+		if _, err := LoadNPY[int32](bytes.NewReader(oneDim)); !errors.Is(err, ErrShape) {
+			t.Errorf("1-D array: want ErrShape, got %v", err)
+		}
+		if _, err := LoadNPY[int32](strings.NewReader("not npy data")); !errors.Is(err, ErrFormat) {
+			t.Errorf("bad magic: want ErrFormat, got %v", err)
+		}
+
+		header := func(shape string) []byte {
+			dict := "{'descr': '<f8', 'fortran_order': False, 'shape': " + shape + ", }\n"
+			var b bytes.Buffer
+			b.WriteString(npyMagic + "\x01\x00")
+			binary.Write(&b, binary.LittleEndian, uint16(len(dict)))
+			b.WriteString(dict)
+			return b.Bytes()
+		}
+		if _, err := LoadNPY[float64](bytes.NewReader(header("(1099511627776, 1048576)"))); !errors.Is(err, ErrFormat) {
+			t.Errorf("oversized shape: want ErrFormat, got %v", err)
+		}
+		if _, err := LoadNPY[float64](bytes.NewReader(header("(1048576, 256)"))); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("large shape without data: want io.ErrUnexpectedEOF, got %v", err)
+		}
+		longHeader := []byte(npyMagic + "\x02\x00\xff\xff\xff\xff")
+		if _, err := LoadNPY[float64](bytes.NewReader(longHeader)); !errors.Is(err, ErrFormat) {
+			t.Errorf("oversized header length: want ErrFormat, got %v", err)
+		}
+	})
+}
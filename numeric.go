@@ -0,0 +1,554 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"fmt"
+	"math"
+)
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Ordered is a constraint that permits any type that supports the ordering
+// operators < <= >= >, mirroring golang.org/x/exp/constraints.Ordered.
+type Ordered interface {
+	Number | ~string
+}
+
+// Add returns a new array holding the element-wise sum a + b.
+// It returns ErrShape if the arrays do not have the same dimensions.
+// The result has the same memory layout as a.
+func Add[T Number](a, b Array2D[T]) (Array2D[T], error) {
+	return zipWith(a, b, func(x, y T) T { return x + y })
+}
+
+// Sub returns a new array holding the element-wise difference a - b.
+// It returns ErrShape if the arrays do not have the same dimensions.
+// The result has the same memory layout as a.
+func Sub[T Number](a, b Array2D[T]) (Array2D[T], error) {
+	return zipWith(a, b, func(x, y T) T { return x - y })
+}
+
+// Mul returns a new array holding the element-wise (Hadamard) product a * b.
+// It returns ErrShape if the arrays do not have the same dimensions.
+// The result has the same memory layout as a.
+func Mul[T Number](a, b Array2D[T]) (Array2D[T], error) {
+	return zipWith(a, b, func(x, y T) T { return x * y })
+}
+
+// Div returns a new array holding the element-wise quotient a / b.
+// It returns ErrShape if the arrays do not have the same dimensions.
+// The result has the same memory layout as a.
+//
+// Division follows Go's rules for T: for integer types a zero divisor panics,
+// while for floating-point types it yields an infinity or NaN.
+func Div[T Number](a, b Array2D[T]) (Array2D[T], error) {
+	return zipWith(a, b, func(x, y T) T { return x / y })
+}
+
+// zipWith returns a new array with the memory layout of a in which each cell
+// holds op applied to the corresponding cells of a and b.
+func zipWith[T any](a, b Array2D[T], op func(x, y T) T) (Array2D[T], error) {
+	if a.height != b.height || a.width != b.width {
+		return Array2D[T]{}, fmt.Errorf("%w: %dx%d does not match %dx%d", ErrShape, a.height, a.width, b.height, b.width)
+	}
+	result := New[T](a.height, a.width, a.colMajor)
+	if a.colMajor == b.colMajor {
+		count, _ := a.lines()
+		for i := 0; i < count; i++ {
+			dst, y := result.line(i), b.line(i)
+			for j, x := range a.line(i) {
+				dst[j] = op(x, y[j])
+			}
+		}
+		return result, nil
+	}
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			result.setUnchecked(r, c, op(a.getUnchecked(r, c), b.getUnchecked(r, c)))
+		}
+	}
+	return result, nil
+}
+
+// Clamp returns a new array with the same memory layout as a in which every
+// element is limited to the range [lo, hi], leaving a unchanged.
+// It panics if lo > hi, since such a range is always a programming error.
+func Clamp[T Ordered](a Array2D[T], lo, hi T) Array2D[T] {
+	result := a.Copy()
+	ClampInPlace(result, lo, hi)
+	return result
+}
+
+// ClampInPlace limits every element of a, in place, to the range [lo, hi].
+// It panics if lo > hi.
+func ClampInPlace[T Ordered](a Array2D[T], lo, hi T) {
+	if lo > hi {
+		panic(fmt.Sprintf("array2d: Clamp called with lo %v greater than hi %v", lo, hi))
+	}
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		line := a.line(i)
+		for j, v := range line {
+			if v < lo {
+				line[j] = lo
+			} else if v > hi {
+				line[j] = hi
+			}
+		}
+	}
+}
+
+// Scale returns a new array with every element of a multiplied by k.
+// The result has the same memory layout as a.
+func Scale[T Number](a Array2D[T], k T) Array2D[T] {
+	return Map(a, func(v T) T { return v * k })
+}
+
+// ScaleInPlace multiplies every element of a by k, in place.
+func ScaleInPlace[T Number](a Array2D[T], k T) {
+	a.MapInPlace(func(v T) T { return v * k })
+}
+
+// AddScalar returns a new array with k added to every element of a.
+// The result has the same memory layout as a.
+func AddScalar[T Number](a Array2D[T], k T) Array2D[T] {
+	return Map(a, func(v T) T { return v + k })
+}
+
+// AddScalarInPlace adds k to every element of a, in place.
+func AddScalarInPlace[T Number](a Array2D[T], k T) {
+	a.MapInPlace(func(v T) T { return v + k })
+}
+
+// Sum returns the sum of all elements of a, or zero for an empty array.
+func Sum[T Number](a Array2D[T]) T {
+	var sum T
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		for _, v := range a.line(i) {
+			sum += v
+		}
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of all elements of a, computed in float64.
+// It returns NaN for an empty array.
+func Mean[T Number](a Array2D[T]) float64 {
+	n := a.height * a.width
+	if n == 0 {
+		return math.NaN()
+	}
+	var sum float64
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		for _, v := range a.line(i) {
+			sum += float64(v)
+		}
+	}
+	return sum / float64(n)
+}
+
+// Min returns the smallest element of a, ignoring NaN elements. ok is false
+// if a has no other elements.
+func Min[T Ordered](a Array2D[T]) (lo T, ok bool) {
+	lo, _, ok = MinMax(a)
+	return lo, ok
+}
+
+// Max returns the largest element of a, ignoring NaN elements. ok is false if
+// a has no other elements.
+func Max[T Ordered](a Array2D[T]) (hi T, ok bool) {
+	_, hi, ok = MinMax(a)
+	return hi, ok
+}
+
+// MinMax returns the smallest and the largest element of a in a single pass.
+// NaN elements are ignored wherever they appear, since they compare neither
+// less nor greater than anything; ok is false if a has no other elements.
+func MinMax[T Ordered](a Array2D[T]) (lo, hi T, ok bool) {
+	count, _ := a.lines()
+	for i := 0; i < count; i++ {
+		for _, v := range a.line(i) {
+			switch {
+			case v != v: // NaN
+			case !ok:
+				lo, hi, ok = v, v, true
+			case v < lo:
+				lo = v
+			case v > hi:
+				hi = v
+			}
+		}
+	}
+	return lo, hi, ok
+}
+
+// SumRows returns the sum of each row of a, one value per row.
+func SumRows[T Number](a Array2D[T]) []T {
+	return ReduceRows(a, T(0), func(acc, v T) T { return acc + v })
+}
+
+// SumCols returns the sum of each column of a, one value per column.
+func SumCols[T Number](a Array2D[T]) []T {
+	return ReduceCols(a, T(0), func(acc, v T) T { return acc + v })
+}
+
+// MeanRows returns the arithmetic mean of each row of a, computed in float64.
+// The means are NaN if a has no columns.
+func MeanRows[T Number](a Array2D[T]) []float64 {
+	means := ReduceRows(a, 0.0, func(acc float64, v T) float64 { return acc + float64(v) })
+	for i := range means {
+		means[i] /= float64(a.width)
+	}
+	return means
+}
+
+// MeanCols returns the arithmetic mean of each column of a, computed in
+// float64. The means are NaN if a has no rows.
+func MeanCols[T Number](a Array2D[T]) []float64 {
+	means := ReduceCols(a, 0.0, func(acc float64, v T) float64 { return acc + float64(v) })
+	for i := range means {
+		means[i] /= float64(a.height)
+	}
+	return means
+}
+
+// ArgMax returns the position of the largest element of a. If the maximum
+// occurs more than once, the first occurrence in logical row-major order is
+// returned. ok is false for an empty array.
+func ArgMax[T Ordered](a Array2D[T]) (pos Coord, ok bool) {
+	return argExtreme(a, func(v, best T) bool { return v > best })
+}
+
+// ArgMin returns the position of the smallest element of a. If the minimum
+// occurs more than once, the first occurrence in logical row-major order is
+// returned. ok is false for an empty array.
+func ArgMin[T Ordered](a Array2D[T]) (pos Coord, ok bool) {
+	return argExtreme(a, func(v, best T) bool { return v < best })
+}
+
+// ArgMaxRows returns, for each row of a, the column index of its largest
+// element (the leftmost one on ties), or -1 if a has no columns.
+func ArgMaxRows[T Ordered](a Array2D[T]) []int {
+	return argExtremeRows(a, func(v, best T) bool { return v > best })
+}
+
+// ArgMinRows returns, for each row of a, the column index of its smallest
+// element (the leftmost one on ties), or -1 if a has no columns.
+func ArgMinRows[T Ordered](a Array2D[T]) []int {
+	return argExtremeRows(a, func(v, best T) bool { return v < best })
+}
+
+// ArgMaxCols returns, for each column of a, the row index of its largest
+// element (the topmost one on ties), or -1 if a has no rows.
+func ArgMaxCols[T Ordered](a Array2D[T]) []int {
+	return argExtremeRows(a.TransposeView(), func(v, best T) bool { return v > best })
+}
+
+// ArgMinCols returns, for each column of a, the row index of its smallest
+// element (the topmost one on ties), or -1 if a has no rows.
+func ArgMinCols[T Ordered](a Array2D[T]) []int {
+	return argExtremeRows(a.TransposeView(), func(v, best T) bool { return v < best })
+}
+
+// argExtreme returns the position of the best element of a according to
+// better, preferring the first one in logical row-major order on ties.
+func argExtreme[T any](a Array2D[T], better func(v, best T) bool) (Coord, bool) {
+	if a.height == 0 || a.width == 0 {
+		return Coord{}, false
+	}
+	var pos Coord
+	best := a.getUnchecked(0, 0)
+	for r := 0; r < a.height; r++ {
+		for c := 0; c < a.width; c++ {
+			if v := a.getUnchecked(r, c); better(v, best) {
+				best, pos = v, Coord{Row: r, Col: c}
+			}
+		}
+	}
+	return pos, true
+}
+
+// argExtremeRows returns the column index of the best element of each row,
+// preferring the leftmost one on ties.
+func argExtremeRows[T any](a Array2D[T], better func(v, best T) bool) []int {
+	result := make([]int, a.height)
+	for r := range result {
+		result[r] = -1
+		if a.width == 0 {
+			continue
+		}
+		best := a.getUnchecked(r, 0)
+		result[r] = 0
+		for c := 1; c < a.width; c++ {
+			if v := a.getUnchecked(r, c); better(v, best) {
+				best, result[r] = v, c
+			}
+		}
+	}
+	return result
+}
+
+// Determinant returns the determinant of the square array a, computed in
+// float64 by Gaussian elimination with partial pivoting. The determinant of a
+// 0x0 array is 1. It returns ErrShape if a is not square.
+func Determinant[T Number](a Array2D[T]) (float64, error) {
+	if a.height != a.width {
+		return 0, fmt.Errorf("%w: determinant requires a square array, got %dx%d", ErrShape, a.height, a.width)
+	}
+	n := a.height
+	m := Map(a, func(v T) float64 { return float64(v) }).ToJagged(nil)
+	det := 1.0
+	for k := 0; k < n; k++ {
+		pivot := k
+		for r := k + 1; r < n; r++ {
+			if math.Abs(m[r][k]) > math.Abs(m[pivot][k]) {
+				pivot = r
+			}
+		}
+		if m[pivot][k] == 0 {
+			return 0, nil
+		}
+		if pivot != k {
+			m[pivot], m[k] = m[k], m[pivot]
+			det = -det
+		}
+		det *= m[k][k]
+		for r := k + 1; r < n; r++ {
+			f := m[r][k] / m[k][k]
+			for c := k + 1; c < n; c++ {
+				m[r][c] -= f * m[k][c]
+			}
+		}
+	}
+	return det, nil
+}
+
+// Gram returns the Gram matrix AᵀA of a: a Width() x Width() row-major array
+// whose cell (i, j) is the dot product of columns i and j of a. Only the upper
+// triangle is computed and then mirrored, and the transpose of a is never
+// materialized.
+func Gram[T Number](a Array2D[T]) Array2D[T] {
+	n := a.width
+	g := New[T](n, n)
+	if a.colMajor {
+		// Columns are contiguous: each cell is a dot product of two lines.
+		for i := 0; i < n; i++ {
+			ci := a.line(i)
+			for j := i; j < n; j++ {
+				var sum T
+				for k, v := range a.line(j) {
+					sum += ci[k] * v
+				}
+				g.setUnchecked(i, j, sum)
+			}
+		}
+	} else {
+		// Rows are contiguous: accumulate the outer product of each row.
+		for r := 0; r < a.height; r++ {
+			row := a.line(r)
+			for i, vi := range row {
+				gi := g.line(i)
+				for j := i; j < n; j++ {
+					gi[j] += vi * row[j]
+				}
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			g.setUnchecked(i, j, g.getUnchecked(j, i))
+		}
+	}
+	return g
+}
+
+// matMulBlock is the edge length of the square tiles MatMul works on. A tile
+// of float64 values of this size fits comfortably in a typical L1 cache.
+const matMulBlock = 64
+
+// MatMul returns the matrix product of a and b as a new row-major array of
+// size a.Height() x b.Width(). It returns ErrShape if a.Width() differs from
+// b.Height().
+//
+// The loops are tiled so that each block of the operands is reused while it is
+// still in cache. The fastest case is a row-major a and a column-major b,
+// where every cell of the result is the dot product of two contiguous lines;
+// a column-major a is first copied to row-major.
+func MatMul[T Number](a, b Array2D[T]) (Array2D[T], error) {
+	if a.width != b.height {
+		return Array2D[T]{}, fmt.Errorf("%w: cannot multiply %dx%d by %dx%d", ErrShape, a.height, a.width, b.height, b.width)
+	}
+	if a.colMajor {
+		rowMajor := New[T](a.height, a.width)
+		_ = rowMajor.CopyFrom(a)
+		a = rowMajor
+	}
+	n, m, p := a.height, a.width, b.width
+	result := New[T](n, p)
+	if n == 0 || m == 0 || p == 0 {
+		return result, nil
+	}
+	if b.colMajor {
+		// Rows of a and columns of b are contiguous: each cell is a dot product.
+		for i0 := 0; i0 < n; i0 += matMulBlock {
+			i1 := blockEnd(i0, n)
+			for j0 := 0; j0 < p; j0 += matMulBlock {
+				j1 := blockEnd(j0, p)
+				for i := i0; i < i1; i++ {
+					ai, ci := a.line(i), result.line(i)
+					for j := j0; j < j1; j++ {
+						var sum T
+						for k, v := range b.line(j) {
+							sum += ai[k] * v
+						}
+						ci[j] = sum
+					}
+				}
+			}
+		}
+		return result, nil
+	}
+	// Rows of b are contiguous: accumulate scaled rows of b into each result
+	// row (i-k-j order), tile by tile.
+	for i0 := 0; i0 < n; i0 += matMulBlock {
+		i1 := blockEnd(i0, n)
+		for k0 := 0; k0 < m; k0 += matMulBlock {
+			k1 := blockEnd(k0, m)
+			for j0 := 0; j0 < p; j0 += matMulBlock {
+				j1 := blockEnd(j0, p)
+				for i := i0; i < i1; i++ {
+					ai, ci := a.line(i), result.line(i)[j0:j1]
+					for k := k0; k < k1; k++ {
+						aik, bk := ai[k], b.line(k)[j0:j1]
+						for j, v := range bk {
+							ci[j] += aik * v
+						}
+					}
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// blockEnd returns the exclusive end of the tile starting at start, clipped
+// to n.
+func blockEnd(start, n int) int {
+	if end := start + matMulBlock; end < n {
+		return end
+	}
+	return n
+}
+
+// CumSumRows returns a new array in which each row holds the running total of
+// the corresponding row of a, from left to right. The result has the same
+// memory layout as a.
+func CumSumRows[T Number](a Array2D[T]) Array2D[T] {
+	result := New[T](a.height, a.width, a.colMajor)
+	for r := 0; r < a.height; r++ {
+		var sum T
+		for c := 0; c < a.width; c++ {
+			sum += a.getUnchecked(r, c)
+			result.setUnchecked(r, c, sum)
+		}
+	}
+	return result
+}
+
+// CumSumCols returns a new array in which each column holds the running total
+// of the corresponding column of a, from top to bottom. The result has the same
+// memory layout as a.
+func CumSumCols[T Number](a Array2D[T]) Array2D[T] {
+	result := New[T](a.height, a.width, a.colMajor)
+	for c := 0; c < a.width; c++ {
+		var sum T
+		for r := 0; r < a.height; r++ {
+			sum += a.getUnchecked(r, c)
+			result.setUnchecked(r, c, sum)
+		}
+	}
+	return result
+}
+
+// SummedAreaTable returns the summed-area table (integral image) of a: a new
+// array with the same dimensions and memory layout in which cell (r, c) holds
+// the sum of all cells of a in rows 0..r and columns 0..c.
+//
+// The sum of any inclusive region (r1, c1)-(r2, c2) can then be computed in
+// constant time as
+//
+//	S(r2, c2) - S(r1-1, c2) - S(r2, c1-1) + S(r1-1, c1-1)
+//
+// where terms with a negative index are zero.
+func SummedAreaTable[T Number](a Array2D[T]) Array2D[T] {
+	sat := New[T](a.height, a.width, a.colMajor)
+	for r := 0; r < a.height; r++ {
+		var rowSum T
+		for c := 0; c < a.width; c++ {
+			rowSum += a.getUnchecked(r, c)
+			v := rowSum
+			if r > 0 {
+				v += sat.getUnchecked(r-1, c)
+			}
+			sat.setUnchecked(r, c, v)
+		}
+	}
+	return sat
+}
+
+// Integral answers rectangular-region sum queries over an array in constant
+// time, using the summed-area table built by IntegralImage.
+type Integral[T Number] struct {
+	sums Array2D[T]
+}
+
+// IntegralImage builds the summed-area table of a in O(Height()*Width()) time
+// and returns it as an Integral ready for RegionSum queries. Later changes to a
+// are not reflected in the result.
+func IntegralImage[T Number](a Array2D[T]) Integral[T] {
+	return Integral[T]{sums: SummedAreaTable(a)}
+}
+
+// Table returns the underlying summed-area table, as returned by
+// SummedAreaTable. It shares storage with g and must not be modified.
+func (g Integral[T]) Table() Array2D[T] {
+	return g.sums
+}
+
+// RegionSum returns the sum of the inclusive region with corners (row1, col1)
+// and (row2, col2) of the original array. The corners may be given in any
+// order, as with Fill.
+//
+// It returns an error if any of the coordinates are out of bounds.
+func (g Integral[T]) RegionSum(row1, col1, row2, col2 int) (T, error) {
+	if err := checkRegion(g.sums.height, g.sums.width, row1, col1, row2, col2); err != nil {
+		var zero T
+		return zero, err
+	}
+	row1, col1, row2, col2 = sortRegion(row1, col1, row2, col2)
+	sum := g.sums.getUnchecked(row2, col2)
+	if row1 > 0 {
+		sum -= g.sums.getUnchecked(row1-1, col2)
+	}
+	if col1 > 0 {
+		sum -= g.sums.getUnchecked(row2, col1-1)
+	}
+	if row1 > 0 && col1 > 0 {
+		sum += g.sums.getUnchecked(row1-1, col1-1)
+	}
+	return sum, nil
+}
@@ -0,0 +1,347 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDeterminant(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+		values []int
+		want   float64
+	}{
+		{"2x2", 2, []int{3, 8, 4, 6}, -14},
+		{"3x3", 3, []int{6, 1, 1, 4, -2, 5, 2, 8, 7}, -306},
+		{"3x3 needs pivoting", 3, []int{0, 1, 2, 1, 0, 3, 4, -3, 8}, -2},
+		{"singular", 2, []int{1, 2, 2, 4}, 0},
+		{"empty", 0, nil, 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			arr, _ := FromSlice(tc.height, tc.height, tc.values)
+			got, err := Determinant(arr)
+			if err != nil {
+				t.Fatalf("Determinant() returned an unexpected error: %v", err)
+			}
+			if math.Abs(got-tc.want) > 1e-9 {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := Determinant(New[float64](2, 3)); !errors.Is(err, ErrShape) {
+		t.Errorf("want error to be ErrShape, but it was not. got: %v", err)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	arr, _ := FromSlice(2, 3, []int{-5, 0, 3, 7, 10, 12}, true)
+	ClampInPlace(arr, 0, 10)
+	want := "Array2d[int] 2x3 [[0 3 10] [0 7 10]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("ClampInPlace() with lo > hi did not panic")
+		}
+	}()
+	ClampInPlace(arr, 1, 0)
+}
+
+func TestGram(t *testing.T) {
+	values := [][]int{
+		{1, 2},
+		{3, 4},
+		{5, 6},
+	}
+
+	// Explicit AᵀA for comparison.
+	want := New[int](2, 2)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			sum := 0
+			for k := 0; k < 3; k++ {
+				sum += values[k][i] * values[k][j]
+			}
+			_ = want.Set(i, j, sum)
+		}
+	}
+
+	for _, colMajor := range []bool{false, true} {
+		arr, _ := FromJagged(3, 2, values, colMajor)
+		got := Gram(arr)
+		if got.String() != want.String() {
+			t.Errorf("colMajor=%v: want %v, got %v", colMajor, want, got)
+		}
+	}
+	if want.String() != "Array2d[int] 2x2 [[35 44] [44 56]]" {
+		t.Errorf("unexpected reference result %v", want)
+	}
+}
+
+func TestCumSum(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, colMajor)
+
+		if got, want := CumSumRows(arr).String(), "Array2d[int] 2x3 [[1 3 6] [4 9 15]]"; got != want {
+			t.Errorf("colMajor=%v: CumSumRows(): want %q, got %q", colMajor, want, got)
+		}
+		if got, want := CumSumCols(arr).String(), "Array2d[int] 2x3 [[1 2 3] [5 7 9]]"; got != want {
+			t.Errorf("colMajor=%v: CumSumCols(): want %q, got %q", colMajor, want, got)
+		}
+	}
+}
+
+func TestSummedAreaTable(t *testing.T) {
+	arr := New[int](4, 5)
+	for i := 0; i < arr.Height(); i++ {
+		for j := 0; j < arr.Width(); j++ {
+			_ = arr.Set(i, j, i*5+j)
+		}
+	}
+	sat := SummedAreaTable(arr)
+
+	if got, want := sat.String(), "Array2d[int] 4x5 [[0 1 3 6 10] [5 12 21 32 45] [15 33 54 78 105] [30 64 102 144 190]]"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	// Sum of rows 1-2, cols 2-3 via four lookups.
+	at := func(r, c int) int { return sat.GetOr(r, c, 0) }
+	got := at(2, 3) - at(0, 3) - at(2, 1) + at(0, 1)
+	want := 7 + 8 + 12 + 13
+	if got != want {
+		t.Errorf("region sum: want %d, got %d", want, got)
+	}
+}
+
+func TestElementwise(t *testing.T) {
+	a, _ := FromJagged(2, 2, [][]float64{{6, 8}, {10, 12}})
+	b, _ := FromJagged(2, 2, [][]float64{{3, 2}, {5, 4}}, true)
+
+	tests := []struct {
+		name string
+		op   func(a, b Array2D[float64]) (Array2D[float64], error)
+		want string
+	}{
+		{"Add", Add[float64], "Array2d[float64] 2x2 [[9 10] [15 16]]"},
+		{"Sub", Sub[float64], "Array2d[float64] 2x2 [[3 6] [5 8]]"},
+		{"Mul", Mul[float64], "Array2d[float64] 2x2 [[18 16] [50 48]]"},
+		{"Div", Div[float64], "Array2d[float64] 2x2 [[2 4] [2 3]]"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.op(a, b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got.String())
+			}
+			if got.colMajor {
+				t.Errorf("result does not have the layout of the first operand")
+			}
+		})
+	}
+
+	t.Run("shape mismatch", func(t *testing.T) {
+		if _, err := Add(a, New[float64](2, 3)); !errors.Is(err, ErrShape) {
+			t.Errorf("want ErrShape, got %v", err)
+		}
+	})
+}
+
+func TestScalarOps(t *testing.T) {
+	arr, _ := FromJagged(2, 2, [][]int{{1, 2}, {3, 4}})
+	orig := arr.String()
+
+	if got, want := Scale(arr, 3).String(), "Array2d[int] 2x2 [[3 6] [9 12]]"; got != want {
+		t.Errorf("Scale: want %q, got %q", want, got)
+	}
+	if got, want := AddScalar(arr, -1).String(), "Array2d[int] 2x2 [[0 1] [2 3]]"; got != want {
+		t.Errorf("AddScalar: want %q, got %q", want, got)
+	}
+	if got, want := Clamp(arr, 2, 3).String(), "Array2d[int] 2x2 [[2 2] [3 3]]"; got != want {
+		t.Errorf("Clamp: want %q, got %q", want, got)
+	}
+	if got := arr.String(); got != orig {
+		t.Errorf("copying variants modified the original: %q", got)
+	}
+
+	ScaleInPlace(arr, 2)
+	AddScalarInPlace(arr, 1)
+	if got, want := arr.String(), "Array2d[int] 2x2 [[3 5] [7 9]]"; got != want {
+		t.Errorf("in place: want %q, got %q", want, got)
+	}
+}
+
+func TestMatMul(t *testing.T) {
+	a, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}})
+	b, _ := FromJagged(3, 2, [][]int{{7, 8}, {9, 10}, {11, 12}})
+	want := "Array2d[int] 2x2 [[58 64] [139 154]]"
+
+	for _, aColMajor := range []bool{false, true} {
+		for _, bColMajor := range []bool{false, true} {
+			la, lb := New[int](2, 3, aColMajor), New[int](3, 2, bColMajor)
+			_ = la.CopyFrom(a)
+			_ = lb.CopyFrom(b)
+			got, err := MatMul(la, lb)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != want {
+				t.Errorf("a colMajor=%v, b colMajor=%v: want %q, got %q", aColMajor, bColMajor, want, got.String())
+			}
+		}
+	}
+
+	t.Run("larger than a block", func(t *testing.T) {
+		n := matMulBlock + 3
+		x := MapIndexed(New[float64](n, n), func(r, c int, _ float64) float64 { return float64(r*n + c) })
+		identity := New[float64](n, n, true)
+		for i := 0; i < n; i++ {
+			identity.Set(i, i, 1)
+		}
+		for _, id := range []Array2D[float64]{identity, identity.Copy().TransposeView()} {
+			got, _ := MatMul(x, id)
+			if diffs, _ := Diff(x, got); len(diffs) != 0 {
+				t.Errorf("x*I differs from x at %d cells", len(diffs))
+			}
+		}
+	})
+
+	t.Run("shape mismatch", func(t *testing.T) {
+		if _, err := MatMul(a, a); !errors.Is(err, ErrShape) {
+			t.Errorf("want ErrShape, got %v", err)
+		}
+	})
+}
+
+func TestAggregates(t *testing.T) {
+	arr, _ := FromJagged(2, 3, [][]int{{4, -2, 9}, {1, 7, 5}}, true)
+
+	if got := Sum(arr); got != 24 {
+		t.Errorf("Sum: want 24, got %d", got)
+	}
+	if got := Mean(arr); got != 4 {
+		t.Errorf("Mean: want 4, got %v", got)
+	}
+	if got, ok := Min(arr); !ok || got != -2 {
+		t.Errorf("Min: want -2, got %d (ok=%v)", got, ok)
+	}
+	if got, ok := Max(arr); !ok || got != 9 {
+		t.Errorf("Max: want 9, got %d (ok=%v)", got, ok)
+	}
+	if lo, hi, ok := MinMax(arr.view(1, 0, 1, 2)); !ok || lo != 1 || hi != 7 {
+		t.Errorf("MinMax of view: want (1, 7), got (%d, %d) (ok=%v)", lo, hi, ok)
+	}
+
+	empty := New[int](0, 3)
+	if _, _, ok := MinMax(empty); ok {
+		t.Errorf("MinMax of empty array: want ok=false")
+	}
+	if got := Mean(empty); !math.IsNaN(got) {
+		t.Errorf("Mean of empty array: want NaN, got %v", got)
+	}
+
+	nan := math.NaN()
+	for _, vs := range [][]float64{{nan, 3, -1}, {3, nan, -1}, {3, -1, nan}} {
+		arr, _ := FromSlice(1, 3, vs)
+		if lo, hi, ok := MinMax(arr); !ok || lo != -1 || hi != 3 {
+			t.Errorf("MinMax(%v): want (-1, 3), got (%v, %v) (ok=%v)", vs, lo, hi, ok)
+		}
+	}
+	if _, ok := Min(NewFilled(2, 2, nan)); ok {
+		t.Errorf("Min of all-NaN array: want ok=false")
+	}
+}
+
+func TestAxisAggregates(t *testing.T) {
+	arr, _ := FromJagged(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}}, true)
+
+	if got, want := SumRows(arr), []int{6, 15}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SumRows: want %v, got %v", want, got)
+	}
+	if got, want := SumCols(arr), []int{5, 7, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SumCols: want %v, got %v", want, got)
+	}
+	if got, want := MeanRows(arr), []float64{2, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MeanRows: want %v, got %v", want, got)
+	}
+	if got, want := MeanCols(arr), []float64{2.5, 3.5, 4.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MeanCols: want %v, got %v", want, got)
+	}
+}
+
+func TestArgExtremes(t *testing.T) {
+	arr, _ := FromJagged(3, 3, [][]int{
+		{3, 9, 1},
+		{9, 0, 4},
+		{2, 0, 7},
+	}, true)
+
+	if got, ok := ArgMax(arr); !ok || got != (Coord{0, 1}) {
+		t.Errorf("ArgMax: want {0 1}, got %v (ok=%v)", got, ok)
+	}
+	if got, ok := ArgMin(arr); !ok || got != (Coord{1, 1}) {
+		t.Errorf("ArgMin: want {1 1}, got %v (ok=%v)", got, ok)
+	}
+	if _, ok := ArgMax(New[int](2, 0)); ok {
+		t.Errorf("ArgMax of empty array: want ok=false")
+	}
+
+	tests := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{"ArgMaxRows", ArgMaxRows(arr), []int{1, 0, 2}},
+		{"ArgMinRows", ArgMinRows(arr), []int{2, 1, 1}},
+		{"ArgMaxCols", ArgMaxCols(arr), []int{1, 0, 2}},
+		{"ArgMinCols", ArgMinCols(arr), []int{2, 1, 0}},
+		{"no columns", ArgMaxRows(New[int](2, 0)), []int{-1, -1}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: want %v, got %v", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestIntegralImage(t *testing.T) {
+	arr, _ := FromJagged(3, 4, [][]int{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{9, 10, 11, 12},
+	}, true)
+	integral := IntegralImage(arr)
+
+	for r1 := 0; r1 < 3; r1++ {
+		for c1 := 0; c1 < 4; c1++ {
+			for r2 := r1; r2 < 3; r2++ {
+				for c2 := c1; c2 < 4; c2++ {
+					region, _ := arr.SubArray(r1, c1, r2, c2)
+					got, err := integral.RegionSum(r2, c2, r1, c1)
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if want := Sum(region); got != want {
+						t.Errorf("(%d,%d)-(%d,%d): want %d, got %d", r1, c1, r2, c2, want, got)
+					}
+				}
+			}
+		}
+	}
+
+	if _, err := integral.RegionSum(0, 0, 3, 0); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("want ErrOutOfBounds, got %v", err)
+	}
+}
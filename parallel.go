@@ -0,0 +1,45 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelForEachRow calls fn for every row of the array using a pool of
+// worker goroutines, and returns once all calls have completed. If workers is
+// not positive, runtime.GOMAXPROCS(0) workers are used.
+//
+// fn receives each row as returned by Row: a slice aliasing the array for
+// row-major arrays and a copy for column-major arrays. Rows are processed in
+// no particular order and fn is invoked concurrently, so it must be safe for
+// concurrent use. Each call receives a distinct row, so writing to values does
+// not race with other calls.
+func (a Array2D[T]) ParallelForEachRow(workers int, fn func(row int, values []T)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > a.height {
+		workers = a.height
+	}
+
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for r := range rows {
+				values, _ := a.Row(r)
+				fn(r, values)
+			}
+		}()
+	}
+	for r := 0; r < a.height; r++ {
+		rows <- r
+	}
+	close(rows)
+	wg.Wait()
+}
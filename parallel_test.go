@@ -0,0 +1,40 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArray2D_ParallelForEachRow(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr := New[int](50, 7, colMajor)
+		for i := 0; i < arr.Height(); i++ {
+			for j := 0; j < arr.Width(); j++ {
+				_ = arr.Set(i, j, i*j)
+			}
+		}
+
+		want := make([]int, arr.Height())
+		for r := range want {
+			row, _ := arr.Row(r)
+			for _, v := range row {
+				want[r] += v
+			}
+		}
+
+		for _, workers := range []int{0, 1, 4, 100} {
+			got := make([]int, arr.Height())
+			arr.ParallelForEachRow(workers, func(row int, values []int) {
+				for _, v := range values {
+					got[row] += v
+				}
+			})
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("colMajor=%v, workers=%d: want %v, got %v", colMajor, workers, want, got)
+			}
+		}
+	}
+}
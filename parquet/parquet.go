@@ -0,0 +1,264 @@
+//go:build go1.18
+// +build go1.18
+
+// Package parquet writes numeric Array2D values as Apache Parquet files, so
+// that tabular data can be handed off to analytics systems.
+//
+// It lives in its own package to keep the format out of the core array2d API.
+// The writer is self-contained and has no dependencies outside the standard
+// library: it produces a single row group with one uncompressed, PLAIN-encoded
+// data page per column, which every Parquet reader understands.
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+
+	"github.com/xll-gen/array2d"
+)
+
+// magic starts and ends every Parquet file.
+const magic = "PAR1"
+
+// Parquet physical types.
+const (
+	typeInt32  = 1
+	typeInt64  = 2
+	typeFloat  = 4
+	typeDouble = 5
+)
+
+// Parquet converted types used to annotate narrow and unsigned integers.
+const (
+	convertedUint8  = 11
+	convertedUint16 = 12
+	convertedUint32 = 13
+	convertedUint64 = 14
+	convertedInt8   = 15
+	convertedInt16  = 16
+)
+
+// Other Parquet enumeration values used by the writer.
+const (
+	repetitionRequired = 0
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecUncompressed  = 0
+	pageTypeData       = 0
+)
+
+// column describes how the element type of an array is stored.
+type column struct {
+	physical  int32
+	converted int32 // 0 if the column needs no annotation
+}
+
+// Write writes a as a Parquet file to w, with one column per array column and
+// one row per array row. colNames names the columns; if it is nil the columns
+// are named "c0", "c1" and so on.
+//
+// Integers of up to 32 bits are stored as INT32 and wider integers as INT64,
+// with narrow and unsigned types annotated accordingly; float32 and float64
+// are stored as FLOAT and DOUBLE. uintptr elements are not supported.
+//
+// It returns an error wrapping array2d.ErrShape if colNames is not nil and its
+// length differs from a.Width().
+func Write[T array2d.Number](w io.Writer, a array2d.Array2D[T], colNames []string) error {
+	height, width := a.Height(), a.Width()
+	if colNames == nil {
+		colNames = make([]string, width)
+		for i := range colNames {
+			colNames[i] = fmt.Sprintf("c%d", i)
+		}
+	}
+	if len(colNames) != width {
+		return fmt.Errorf("%w: %d column names for width %d", array2d.ErrShape, len(colNames), width)
+	}
+	var zero T
+	col, err := columnFor(reflect.TypeOf(zero).Kind())
+	if err != nil {
+		return err
+	}
+
+	cw := &countingWriter{w: w}
+	if _, err := io.WriteString(cw, magic); err != nil {
+		return err
+	}
+	chunks := make([]chunk, width)
+	for c := range chunks {
+		values, _ := a.Col(c)
+		page := encodePlain(values, col.physical)
+		header := &compactWriter{}
+		writePageHeader(header, len(values), len(page))
+
+		chunks[c] = chunk{offset: cw.n, size: int64(header.buf.Len() + len(page))}
+		if _, err := cw.Write(header.buf.Bytes()); err != nil {
+			return err
+		}
+		if _, err := cw.Write(page); err != nil {
+			return err
+		}
+	}
+
+	meta := &compactWriter{}
+	writeFileMetaData(meta, col, colNames, chunks, height)
+	footer := meta.buf.Bytes()
+	var trailer [4 + len(magic)]byte
+	binary.LittleEndian.PutUint32(trailer[:], uint32(len(footer)))
+	copy(trailer[4:], magic)
+	if _, err := cw.Write(footer); err != nil {
+		return err
+	}
+	_, err = cw.Write(trailer[:])
+	return err
+}
+
+// chunk records where a column chunk was written.
+type chunk struct {
+	offset, size int64
+}
+
+// columnFor returns the Parquet representation of elements of the given kind.
+func columnFor(kind reflect.Kind) (column, error) {
+	switch kind {
+	case reflect.Int8:
+		return column{typeInt32, convertedInt8}, nil
+	case reflect.Int16:
+		return column{typeInt32, convertedInt16}, nil
+	case reflect.Int32:
+		return column{typeInt32, 0}, nil
+	case reflect.Int, reflect.Int64:
+		return column{typeInt64, 0}, nil
+	case reflect.Uint8:
+		return column{typeInt32, convertedUint8}, nil
+	case reflect.Uint16:
+		return column{typeInt32, convertedUint16}, nil
+	case reflect.Uint32:
+		return column{typeInt32, convertedUint32}, nil
+	case reflect.Uint, reflect.Uint64:
+		return column{typeInt64, convertedUint64}, nil
+	case reflect.Float32:
+		return column{typeFloat, 0}, nil
+	case reflect.Float64:
+		return column{typeDouble, 0}, nil
+	}
+	return column{}, fmt.Errorf("parquet: unsupported element kind %v", kind)
+}
+
+// encodePlain returns values in the PLAIN encoding of the physical type:
+// little-endian, fixed-width and back to back. Unsigned values are stored
+// bit for bit in the signed physical type, as the Parquet format specifies.
+func encodePlain[T array2d.Number](values []T, physical int32) []byte {
+	var buf []byte
+	switch physical {
+	case typeInt32:
+		buf = make([]byte, 4*len(values))
+		for i, v := range values {
+			binary.LittleEndian.PutUint32(buf[4*i:], uint32(int32(v)))
+		}
+	case typeInt64:
+		buf = make([]byte, 8*len(values))
+		for i, v := range values {
+			binary.LittleEndian.PutUint64(buf[8*i:], uint64(int64(v)))
+		}
+	case typeFloat:
+		buf = make([]byte, 4*len(values))
+		for i, v := range values {
+			binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(float32(v)))
+		}
+	case typeDouble:
+		buf = make([]byte, 8*len(values))
+		for i, v := range values {
+			binary.LittleEndian.PutUint64(buf[8*i:], math.Float64bits(float64(v)))
+		}
+	}
+	return buf
+}
+
+// writePageHeader encodes the PageHeader of a data page holding n required
+// values in size bytes.
+func writePageHeader(w *compactWriter, n, size int) {
+	w.beginStruct()
+	w.i32Field(1, pageTypeData)
+	w.i32Field(2, int32(size)) // uncompressed_page_size
+	w.i32Field(3, int32(size)) // compressed_page_size
+	w.structField(5)           // data_page_header
+	w.i32Field(1, int32(n))    // num_values
+	w.i32Field(2, encodingPlain)
+	w.i32Field(3, encodingRLE) // definition_level_encoding
+	w.i32Field(4, encodingRLE) // repetition_level_encoding
+	w.endStruct()
+	w.endStruct()
+}
+
+// writeFileMetaData encodes the FileMetaData footer describing a single row
+// group with the given column chunks.
+func writeFileMetaData(w *compactWriter, col column, names []string, chunks []chunk, rows int) {
+	w.beginStruct()
+	w.i32Field(1, 1) // version
+
+	w.listField(2, typeStruct, len(names)+1) // schema
+	w.beginStruct()
+	w.stringField(4, "schema")
+	w.i32Field(5, int32(len(names))) // num_children
+	w.endStruct()
+	for _, name := range names {
+		w.beginStruct()
+		w.i32Field(1, col.physical)
+		w.i32Field(3, repetitionRequired)
+		w.stringField(4, name)
+		if col.converted != 0 {
+			w.i32Field(6, col.converted)
+		}
+		w.endStruct()
+	}
+
+	w.i64Field(3, int64(rows)) // num_rows
+
+	var total int64
+	for _, ch := range chunks {
+		total += ch.size
+	}
+	w.listField(4, typeStruct, 1) // row_groups
+	w.beginStruct()
+	w.listField(1, typeStruct, len(chunks)) // columns
+	for i, ch := range chunks {
+		w.beginStruct()
+		w.i64Field(2, ch.offset) // file_offset
+		w.structField(3)         // meta_data
+		w.i32Field(1, col.physical)
+		w.listField(2, typeI32, 1) // encodings
+		w.varint(encodingPlain)
+		w.listField(3, typeBinary, 1) // path_in_schema
+		w.string(names[i])
+		w.i32Field(4, codecUncompressed)
+		w.i64Field(5, int64(rows)) // num_values
+		w.i64Field(6, ch.size)     // total_uncompressed_size
+		w.i64Field(7, ch.size)     // total_compressed_size
+		w.i64Field(9, ch.offset)   // data_page_offset
+		w.endStruct()
+		w.endStruct()
+	}
+	w.i64Field(2, total)       // total_byte_size
+	w.i64Field(3, int64(rows)) // num_rows
+	w.endStruct()
+
+	w.stringField(6, "github.com/xll-gen/array2d/parquet") // created_by
+	w.endStruct()
+}
+
+// countingWriter counts the bytes written to the underlying writer, which
+// gives the file offsets recorded in the metadata.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,148 @@
+//go:build go1.18
+// +build go1.18
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/xll-gen/array2d"
+)
+
+// compactReader decodes the subset of the Thrift compact protocol written by
+// compactWriter into generic values: structs become map[int16]any keyed by
+// field id and lists become []any.
+type compactReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *compactReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *compactReader) varint() int64 {
+	u := r.uvarint()
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (r *compactReader) value(typ byte) any {
+	switch typ {
+	case typeI32, typeI64:
+		return r.varint()
+	case typeBinary:
+		n := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case typeList:
+		header := r.data[r.pos]
+		r.pos++
+		size, elem := int(header>>4), header&0x0f
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case typeStruct:
+		fields := map[int16]any{}
+		var id int16
+		for {
+			header := r.data[r.pos]
+			r.pos++
+			if header == 0 {
+				return fields
+			}
+			if delta := int16(header >> 4); delta != 0 {
+				id += delta
+			} else {
+				id = int16(r.varint())
+			}
+			fields[id] = r.value(header & 0x0f)
+		}
+	}
+	panic("unsupported thrift type")
+}
+
+func TestWrite(t *testing.T) {
+	arr, _ := array2d.FromJagged(3, 2, [][]float64{{1.5, -2}, {3, 4}, {5, 6.25}}, true)
+	var buf bytes.Buffer
+	if err := Write(&buf, arr, []string{"x", "y"}); err != nil {
+		t.Fatalf("Write() returned an unexpected error: %v", err)
+	}
+	file := buf.Bytes()
+	if string(file[:4]) != magic || string(file[len(file)-4:]) != magic {
+		t.Fatalf("file does not start and end with %q", magic)
+	}
+	footerLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := file[len(file)-8-footerLen : len(file)-8]
+	meta := (&compactReader{data: footer}).value(typeStruct).(map[int16]any)
+
+	if meta[3] != int64(3) {
+		t.Errorf("num_rows: want 3, got %v", meta[3])
+	}
+	schema := meta[2].([]any)
+	var names []any
+	for _, el := range schema[1:] {
+		fields := el.(map[int16]any)
+		if fields[1] != int64(typeDouble) {
+			t.Errorf("column %v: want DOUBLE, got type %v", fields[4], fields[1])
+		}
+		names = append(names, fields[4])
+	}
+	if want := []any{"x", "y"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("column names: want %v, got %v", want, names)
+	}
+
+	columns := meta[4].([]any)[0].(map[int16]any)[1].([]any)
+	for c, el := range columns {
+		md := el.(map[int16]any)[3].(map[int16]any)
+		offset := int(md[9].(int64))
+		pr := &compactReader{data: file, pos: offset}
+		header := pr.value(typeStruct).(map[int16]any)
+		if n := header[5].(map[int16]any)[1]; n != int64(3) {
+			t.Errorf("column %d: want 3 values in page, got %v", c, n)
+		}
+		if size := int64(pr.pos-offset) + header[3].(int64); size != md[7].(int64) {
+			t.Errorf("column %d: chunk size %d does not match metadata %v", c, size, md[7])
+		}
+		want, _ := arr.Col(c)
+		for i, v := range want {
+			bits := binary.LittleEndian.Uint64(file[pr.pos+8*i:])
+			if got := math.Float64frombits(bits); got != v {
+				t.Errorf("column %d value %d: want %v, got %v", c, i, v, got)
+			}
+		}
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		arr, _ := array2d.FromSlice(1, 1, []uint32{math.MaxUint32})
+		var buf bytes.Buffer
+		if err := Write(&buf, arr, nil); err != nil {
+			t.Fatalf("Write() returned an unexpected error: %v", err)
+		}
+		file := buf.Bytes()
+		footerLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+		meta := (&compactReader{data: file[len(file)-8-footerLen:]}).value(typeStruct).(map[int16]any)
+		leaf := meta[2].([]any)[1].(map[int16]any)
+		if leaf[1] != int64(typeInt32) || leaf[6] != int64(convertedUint32) || leaf[4] != "c0" {
+			t.Errorf("want INT32 column c0 annotated UINT_32, got %v", leaf)
+		}
+	})
+
+	t.Run("column names mismatch", func(t *testing.T) {
+		if err := Write(&bytes.Buffer{}, arr, []string{"x"}); !errors.Is(err, array2d.ErrShape) {
+			t.Errorf("want ErrShape, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+//go:build go1.18
+// +build go1.18
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type identifiers.
+const (
+	typeI32    = 5
+	typeI64    = 6
+	typeBinary = 8
+	typeList   = 9
+	typeStruct = 12
+)
+
+// compactWriter encodes Thrift structures with the compact protocol, which is
+// how Parquet serializes its page headers and file metadata. Only the subset
+// of the protocol needed by this package is implemented.
+type compactWriter struct {
+	buf bytes.Buffer
+	// lastField holds the id of the last field written in each open struct,
+	// innermost last, for the delta-encoded field headers.
+	lastField []int16
+}
+
+func (w *compactWriter) beginStruct() {
+	w.lastField = append(w.lastField, 0)
+}
+
+func (w *compactWriter) endStruct() {
+	w.buf.WriteByte(0)
+	w.lastField = w.lastField[:len(w.lastField)-1]
+}
+
+func (w *compactWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastField[len(w.lastField)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+func (w *compactWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, typeI32)
+	w.varint(int64(v))
+}
+
+func (w *compactWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, typeI64)
+	w.varint(v)
+}
+
+func (w *compactWriter) stringField(id int16, s string) {
+	w.fieldHeader(id, typeBinary)
+	w.string(s)
+}
+
+func (w *compactWriter) structField(id int16) {
+	w.fieldHeader(id, typeStruct)
+	w.beginStruct()
+}
+
+func (w *compactWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, typeList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.uvarint(uint64(size))
+	}
+}
+
+func (w *compactWriter) string(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+// varint writes a zigzag-encoded signed integer.
+func (w *compactWriter) varint(v int64) {
+	w.uvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+func (w *compactWriter) uvarint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	w.buf.Write(tmp[:binary.PutUvarint(tmp[:], v)])
+}
@@ -0,0 +1,33 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import "testing"
+
+func TestPool(t *testing.T) {
+	pool := NewPool[int](3, 4)
+
+	arr := pool.Get()
+	if arr.Height() != 3 || arr.Width() != 4 {
+		t.Fatalf("want 3x4 array, got %dx%d", arr.Height(), arr.Width())
+	}
+	arr.SetAll(9)
+	pool.Put(arr)
+
+	// A mismatched shape must be ignored rather than handed out later.
+	pool.Put(New[int](2, 2))
+
+	for i := 0; i < 3; i++ {
+		got := pool.Get()
+		if got.Height() != 3 || got.Width() != 4 {
+			t.Fatalf("want 3x4 array, got %dx%d", got.Height(), got.Width())
+		}
+		for _, v := range got.Values() {
+			if v != 0 {
+				t.Fatalf("want cleared array, got %v", got)
+			}
+		}
+		pool.Put(got)
+	}
+}
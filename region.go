@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+// Region is a handle to an inclusive rectangular region of an Array2D. It
+// records the bounds once so that successive operations on the same window do
+// not need to repeat the coordinates. Operations act directly on the parent
+// array.
+type Region[T any] struct {
+	arr                    Array2D[T]
+	row1, col1, row2, col2 int
+}
+
+// Region returns a handle to the inclusive region with corners (row1, col1)
+// and (row2, col2). The corners may be given in any order, as with Fill.
+//
+// It returns an error if any of the coordinates are out of bounds.
+func (a Array2D[T]) Region(row1, col1, row2, col2 int) (Region[T], error) {
+	if err := checkRegion(a.height, a.width, row1, col1, row2, col2); err != nil {
+		return Region[T]{}, err
+	}
+	row1, col1, row2, col2 = sortRegion(row1, col1, row2, col2)
+	return Region[T]{arr: a, row1: row1, col1: col1, row2: row2, col2: col2}, nil
+}
+
+// Bounds returns the sorted, inclusive corners of the region.
+func (g Region[T]) Bounds() (row1, col1, row2, col2 int) {
+	return g.row1, g.col1, g.row2, g.col2
+}
+
+// Fill assigns value to every cell of the region.
+func (g Region[T]) Fill(value T) {
+	// The bounds were validated when the region was created.
+	_ = g.arr.Fill(g.row1, g.col1, g.row2, g.col2, value)
+}
+
+// ForEach calls fn for every cell of the region in logical row-major order.
+// The coordinates passed to fn are those of the parent array.
+func (g Region[T]) ForEach(fn func(row, col int, v T)) {
+	_ = g.arr.ForEachInRegion(g.row1, g.col1, g.row2, g.col2, fn)
+}
+
+// SumRegion returns the sum of all values in the region.
+func SumRegion[T Number](g Region[T]) T {
+	var sum T
+	g.ForEach(func(_, _ int, v T) {
+		sum += v
+	})
+	return sum
+}
@@ -0,0 +1,44 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArray2D_Region(t *testing.T) {
+	arr := New[int](4, 4)
+	region, err := arr.Region(2, 2, 1, 1)
+	if err != nil {
+		t.Fatalf("Region() returned an unexpected error: %v", err)
+	}
+	if r1, c1, r2, c2 := region.Bounds(); r1 != 1 || c1 != 1 || r2 != 2 || c2 != 2 {
+		t.Errorf("Bounds(): want (1, 1, 2, 2), got (%d, %d, %d, %d)", r1, c1, r2, c2)
+	}
+
+	region.Fill(3)
+	want := "Array2d[int] 4x4 [[0 0 0 0] [0 3 3 0] [0 3 3 0] [0 0 0 0]]"
+	if got := arr.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if got := SumRegion(region); got != 12 {
+		t.Errorf("SumRegion(): want 12, got %d", got)
+	}
+
+	visited := 0
+	region.ForEach(func(row, col int, v int) {
+		visited++
+		if row < 1 || row > 2 || col < 1 || col > 2 {
+			t.Errorf("ForEach visited (%d, %d) outside the region", row, col)
+		}
+	})
+	if visited != 4 {
+		t.Errorf("want 4 visited cells, got %d", visited)
+	}
+
+	if _, err := arr.Region(0, 0, 4, 4); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("want ErrOutOfBounds, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import "fmt"
+
+// RowView is a handle to a single row of an Array2D. It operates directly on
+// the parent array, so writes through it are visible in the array.
+type RowView[T any] struct {
+	arr Array2D[T]
+	row int
+}
+
+// RowView returns a view of the given row.
+// It returns false if the row index is out of bounds.
+func (a Array2D[T]) RowView(row int) (RowView[T], bool) {
+	if row < 0 || row >= a.height {
+		return RowView[T]{}, false
+	}
+	return RowView[T]{arr: a, row: row}, true
+}
+
+// Index returns the index of the row in the parent array.
+func (v RowView[T]) Index() int {
+	return v.row
+}
+
+// Len returns the number of cells in the row, which is the parent's width.
+func (v RowView[T]) Len() int {
+	return v.arr.width
+}
+
+// Get returns the value in the given column of the row.
+// It returns the zero value for T and false if the column is out-of-bounds.
+func (v RowView[T]) Get(col int) (T, bool) {
+	return v.arr.Get(v.row, col)
+}
+
+// Set sets the value in the given column of the row.
+// It returns an error on out-of-bounds access.
+func (v RowView[T]) Set(col int, value T) error {
+	if col < 0 || col >= v.arr.width {
+		return fmt.Errorf("%w: col index %d out of range for width %d", ErrOutOfBounds, col, v.arr.width)
+	}
+	v.arr.setUnchecked(v.row, col, value)
+	return nil
+}
+
+// Values returns the row's values with the same aliasing rules as
+// Array2D.Row: a mutable slice for row-major arrays and a copy for
+// column-major arrays.
+func (v RowView[T]) Values() []T {
+	values, _ := v.arr.Row(v.row)
+	return values
+}
+
+// SumRow returns the sum of all values in the row.
+func SumRow[T Number](v RowView[T]) T {
+	var sum T
+	for c := 0; c < v.arr.width; c++ {
+		sum += v.arr.getUnchecked(v.row, c)
+	}
+	return sum
+}
@@ -0,0 +1,50 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestArray2D_RowView(t *testing.T) {
+	for _, colMajor := range []bool{false, true} {
+		arr, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6})
+		if colMajor {
+			arr, _ = FromSlice(2, 3, []int{1, 4, 2, 5, 3, 6}, true)
+		}
+
+		row, ok := arr.RowView(1)
+		if !ok {
+			t.Fatal("RowView(1) returned ok=false unexpectedly")
+		}
+		if row.Index() != 1 || row.Len() != 3 {
+			t.Errorf("want index 1 and len 3, got index %d and len %d", row.Index(), row.Len())
+		}
+		if got := SumRow(row); got != 15 {
+			t.Errorf("colMajor=%v: SumRow(): want 15, got %d", colMajor, got)
+		}
+
+		if err := row.Set(2, 60); err != nil {
+			t.Fatalf("Set() returned an unexpected error: %v", err)
+		}
+		if got, _ := arr.Get(1, 2); got != 60 {
+			t.Errorf("colMajor=%v: write through RowView not visible in parent, got %d", colMajor, got)
+		}
+		if got, _ := row.Get(2); got != 60 {
+			t.Errorf("colMajor=%v: Get(2): want 60, got %d", colMajor, got)
+		}
+		if want := []int{4, 5, 60}; !reflect.DeepEqual(row.Values(), want) {
+			t.Errorf("colMajor=%v: Values(): want %v, got %v", colMajor, want, row.Values())
+		}
+
+		if err := row.Set(3, 0); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("want ErrOutOfBounds, got %v", err)
+		}
+		if _, ok := arr.RowView(2); ok {
+			t.Error("RowView(2) returned ok=true for an out-of-bounds row")
+		}
+	}
+}
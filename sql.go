@@ -0,0 +1,144 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// SQLBlob wraps an array so that it can be stored in a database column
+// through database/sql. Value writes the array in the MarshalBinary format,
+// which suits BLOB and BYTEA columns and preserves the memory layout.
+//
+// Scan accepts either encoding, so a column written by SQLJSON can be read
+// with SQLBlob and vice versa.
+type SQLBlob[T any] struct {
+	Array2D[T]
+}
+
+// Value implements driver.Valuer.
+func (b SQLBlob[T]) Value() (driver.Value, error) {
+	return b.MarshalBinary()
+}
+
+// Scan implements sql.Scanner. A NULL column yields an empty array.
+func (b *SQLBlob[T]) Scan(src any) error {
+	return scanSQL(&b.Array2D, src)
+}
+
+// SQLJSON wraps an array so that it can be stored in a database column
+// through database/sql. Value writes the array as a JSON array of rows, such
+// as [[1,2,3],[4,5,6]], which suits JSON and text columns and can be queried
+// by the database. The memory layout is not preserved, and scanned arrays are
+// row-major.
+//
+// Scan accepts either encoding, so a column written by SQLBlob can be read
+// with SQLJSON and vice versa.
+type SQLJSON[T any] struct {
+	Array2D[T]
+}
+
+// Value implements driver.Valuer.
+func (j SQLJSON[T]) Value() (driver.Value, error) {
+	rows := j.ToSlices()
+	if rows == nil {
+		rows = [][]T{}
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return nil, fmt.Errorf("array2d: encoding JSON: %w", err)
+	}
+	return data, nil
+}
+
+// Scan implements sql.Scanner. A NULL column yields an empty array.
+func (j *SQLJSON[T]) Scan(src any) error {
+	return scanSQL(&j.Array2D, src)
+}
+
+// scanSQL decodes a column value written by SQLBlob or SQLJSON into a,
+// telling the two encodings apart by the MarshalBinary header.
+func scanSQL[T any](a *Array2D[T], src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*a = Array2D[T]{}
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("array2d: cannot scan %T into an array", src)
+	}
+	if bytes.HasPrefix(data, []byte(binaryMagic)) {
+		return a.UnmarshalBinary(data)
+	}
+	var rows [][]T
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return fmt.Errorf("%w: decoding JSON: %v", ErrFormat, err)
+	}
+	width := 0
+	if len(rows) > 0 {
+		width = len(rows[0])
+	}
+	for i, row := range rows {
+		if len(row) != width {
+			return fmt.Errorf("%w: JSON row %d has %d values, want %d", ErrFormat, i, len(row), width)
+		}
+	}
+	arr, err := FromJagged(len(rows), width, rows)
+	if err != nil {
+		return err
+	}
+	*a = arr
+	return nil
+}
+
+// FromSQLRows reads the remaining rows of a result set into a row-major array
+// with one column per result column, and returns it with the column names.
+// Each value is scanned into an any, so it holds whatever the driver returns,
+// typically int64, float64, bool, []byte, string, time.Time or nil.
+//
+// The rows are closed when FromSQLRows returns.
+func FromSQLRows(rows *sql.Rows) (Array2D[any], []string, error) {
+	return FromSQLRowsAs[any](rows)
+}
+
+// FromSQLRowsAs is like FromSQLRows for result sets whose columns all hold
+// values of the same type. Each value is scanned into a T, so the conversions
+// of Rows.Scan apply and a value that cannot be converted, such as a NULL
+// scanned into a float64, is an error.
+func FromSQLRowsAs[T any](rows *sql.Rows) (Array2D[T], []string, error) {
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return Array2D[T]{}, nil, err
+	}
+	width := len(cols)
+	var slice []T
+	dest := make([]any, width)
+	height := 0
+	for ; rows.Next(); height++ {
+		slice = append(slice, make([]T, width)...)
+		row := slice[height*width:]
+		for i := range dest {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return Array2D[T]{}, nil, fmt.Errorf("array2d: scanning row %d: %w", height, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return Array2D[T]{}, nil, err
+	}
+	if slice == nil {
+		slice = []T{}
+	}
+	return newArray(height, width, slice, false), cols, nil
+}
@@ -0,0 +1,180 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = SQLBlob[int]{}
+	_ sql.Scanner   = (*SQLBlob[int])(nil)
+	_ driver.Valuer = SQLJSON[int]{}
+	_ sql.Scanner   = (*SQLJSON[int])(nil)
+)
+
+func TestSQLBlob(t *testing.T) {
+	a, _ := FromSlice(2, 3, []int{1, 2, 3, 4, 5, 6}, true)
+
+	v, err := SQLBlob[int]{a}.Value()
+	if err != nil {
+		t.Fatalf("Value() returned an unexpected error: %v", err)
+	}
+	var got SQLBlob[int]
+	if err := got.Scan(v); err != nil {
+		t.Fatalf("Scan() returned an unexpected error: %v", err)
+	}
+	if !Equal(got.Array2D, a) || !got.colMajor {
+		t.Errorf("Scan(): want %v in column-major order, got %v", a, got.Array2D)
+	}
+
+	if err := got.Scan(nil); err != nil || got.Height() != 0 || got.Width() != 0 {
+		t.Errorf("Scan(nil): want an empty array, got %v, %v", got.Array2D, err)
+	}
+	if err := got.Scan(42); err == nil {
+		t.Errorf("Scan(42): want error")
+	}
+}
+
+func TestSQLJSON(t *testing.T) {
+	a, _ := FromSlice(2, 3, []int{1, 4, 2, 5, 3, 6}, true)
+
+	v, err := SQLJSON[int]{a}.Value()
+	if err != nil {
+		t.Fatalf("Value() returned an unexpected error: %v", err)
+	}
+	if want := "[[1,2,3],[4,5,6]]"; string(v.([]byte)) != want {
+		t.Errorf("Value(): want %q, got %q", want, v)
+	}
+	if v, _ := (SQLJSON[int]{}).Value(); string(v.([]byte)) != "[]" {
+		t.Errorf("Value() of an empty array: want %q, got %q", "[]", v)
+	}
+
+	var got SQLJSON[int]
+	if err := got.Scan(string(v.([]byte))); err != nil {
+		t.Fatalf("Scan() returned an unexpected error: %v", err)
+	}
+	if !Equal(got.Array2D, a) {
+		t.Errorf("Scan(): want %v, got %v", a, got.Array2D)
+	}
+
+	blob, _ := SQLBlob[int]{a}.Value()
+	if err := got.Scan(blob); err != nil || !Equal(got.Array2D, a) {
+		t.Errorf("Scan() of a blob: want %v, got %v, %v", a, got.Array2D, err)
+	}
+
+	for _, src := range []string{"[[1,2],[3]]", "{}", "[[1,"} {
+		if err := got.Scan(src); !errors.Is(err, ErrFormat) {
+			t.Errorf("Scan(%q): want ErrFormat, got %v", src, err)
+		}
+	}
+}
+
+// testDriver is a database/sql driver whose queries return the result set
+// registered under the query text in testResults.
+type testDriver struct{}
+
+type testResult struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+var testResults = map[string]testResult{
+	"mixed": {
+		cols: []string{"id", "name", "score"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("ann"), 1.5},
+			{int64(2), "bob", nil},
+		},
+	},
+	"floats": {
+		cols: []string{"x", "y"},
+		rows: [][]driver.Value{{1.5, int64(2)}, {[]byte("3.25"), 4.0}},
+	},
+	"empty": {cols: []string{"x"}},
+}
+
+func init() {
+	sql.Register("array2d-test", testDriver{})
+}
+
+func (testDriver) Open(string) (driver.Conn, error) { return testConn{}, nil }
+
+type testConn struct{}
+
+func (testConn) Prepare(query string) (driver.Stmt, error) { return testStmt(query), nil }
+func (testConn) Close() error                              { return nil }
+func (testConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type testStmt string
+
+func (testStmt) Close() error  { return nil }
+func (testStmt) NumInput() int { return 0 }
+func (testStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s testStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &testRows{testResults[string(s)], 0}, nil
+}
+
+type testRows struct {
+	testResult
+	next int
+}
+
+func (r *testRows) Columns() []string { return r.cols }
+func (r *testRows) Close() error      { return nil }
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestFromSQLRows(t *testing.T) {
+	db, err := sql.Open("array2d-test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, _ := db.Query("mixed")
+	a, cols, err := FromSQLRows(rows)
+	if err != nil {
+		t.Fatalf("FromSQLRows() returned an unexpected error: %v", err)
+	}
+	if want := []string{"id", "name", "score"}; !reflect.DeepEqual(cols, want) {
+		t.Errorf("columns: want %v, got %v", want, cols)
+	}
+	want := [][]any{{int64(1), []byte("ann"), 1.5}, {int64(2), "bob", nil}}
+	if got := a.ToSlices(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromSQLRows(): want %v, got %v", want, got)
+	}
+
+	rows, _ = db.Query("floats")
+	f, _, err := FromSQLRowsAs[float64](rows)
+	if err != nil {
+		t.Fatalf("FromSQLRowsAs() returned an unexpected error: %v", err)
+	}
+	if want := "Array2d[float64] 2x2 [[1.5 2] [3.25 4]]"; f.String() != want {
+		t.Errorf("FromSQLRowsAs(): want %q, got %q", want, f.String())
+	}
+
+	rows, _ = db.Query("empty")
+	if e, _, err := FromSQLRowsAs[float64](rows); err != nil || e.Height() != 0 || e.Width() != 1 {
+		t.Errorf("FromSQLRowsAs() of no rows: want a 0x1 array, got %v, %v", e, err)
+	}
+
+	rows, _ = db.Query("mixed")
+	if _, _, err := FromSQLRowsAs[float64](rows); err == nil {
+		t.Errorf("want error scanning strings into float64")
+	}
+}
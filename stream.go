@@ -0,0 +1,214 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// streamMagic identifies data produced by WriteTo.
+const streamMagic = "A2S"
+
+// streamVersion is the version of the WriteTo format. ReadFrom rejects data
+// with any other version.
+const streamVersion = 1
+
+// flagBigEndian is set in the flags byte of the stream header when the
+// elements are big-endian.
+const flagBigEndian = 1 << 1
+
+// streamHeaderSize is the size of the fixed stream header: magic, version,
+// flags, element size (uint32), height and width (uint64 each).
+const streamHeaderSize = len(streamMagic) + 2 + 4 + 8 + 8
+
+// WriteTo implements io.WriterTo. It writes the array to w in a streaming
+// binary format, in little-endian byte order; see WriteToOrder.
+func (a Array2D[T]) WriteTo(w io.Writer) (int64, error) {
+	return a.WriteToOrder(w, binary.LittleEndian)
+}
+
+// WriteToOrder writes the array to w in a streaming binary format: a fixed
+// header holding the element size, height, width, memory layout and byte
+// order, followed by the elements one storage line at a time (row by row for
+// row-major arrays, column by column for column-major arrays). Nothing larger
+// than a single line is buffered, so arrays of any size can be written.
+//
+// T must be a fixed-size type as defined by encoding/binary, such as the
+// numeric types or arrays and structs of them. It returns the number of bytes
+// written.
+func (a Array2D[T]) WriteToOrder(w io.Writer, order binary.ByteOrder) (int64, error) {
+	var zero T
+	size := binary.Size(zero)
+	if size <= 0 {
+		return 0, fmt.Errorf("array2d: element type %T does not have a fixed size", zero)
+	}
+	var header [streamHeaderSize]byte
+	copy(header[:], streamMagic)
+	h := header[len(streamMagic):]
+	h[0] = streamVersion
+	if a.colMajor {
+		h[1] |= flagColMajor
+	}
+	if order == binary.BigEndian {
+		h[1] |= flagBigEndian
+	} else if order != binary.LittleEndian {
+		return 0, fmt.Errorf("array2d: unsupported byte order %v", order)
+	}
+	binary.LittleEndian.PutUint32(h[2:], uint32(size))
+	binary.LittleEndian.PutUint64(h[6:], uint64(a.height))
+	binary.LittleEndian.PutUint64(h[14:], uint64(a.width))
+
+	cw := &countingWriter{w: w}
+	if _, err := cw.Write(header[:]); err != nil {
+		return cw.n, err
+	}
+	count, length := a.lines()
+	if length == 0 {
+		return cw.n, nil
+	}
+	for i := 0; i < count; i++ {
+		if err := binary.Write(cw, order, a.line(i)); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+// ReadFrom implements io.ReaderFrom, replacing a with an array read from r in
+// the format written by WriteTo or WriteToOrder. The byte order and memory
+// layout are taken from the header. It returns the number of bytes read.
+//
+// It returns an error wrapping ErrFormat if the header is malformed, was
+// written by an unsupported version, or describes elements of a different size
+// than T, or dimensions too large to address. If the data ends early, the
+// error wraps io.ErrUnexpectedEOF. Storage is allocated as the data arrives, so
+// a header claiming a huge array cannot force an allocation the stream does
+// not back.
+func (a *Array2D[T]) ReadFrom(r io.Reader) (int64, error) {
+	var zero T
+	size := binary.Size(zero)
+	if size <= 0 {
+		return 0, fmt.Errorf("array2d: element type %T does not have a fixed size", zero)
+	}
+	cr := &countingReader{r: r}
+	var header [streamHeaderSize]byte
+	if _, err := io.ReadFull(cr, header[:]); err != nil {
+		return cr.n, fmt.Errorf("%w: reading header: %v", ErrFormat, err)
+	}
+	if string(header[:len(streamMagic)]) != streamMagic {
+		return cr.n, fmt.Errorf("%w: missing %s header", ErrFormat, streamMagic)
+	}
+	h := header[len(streamMagic):]
+	if h[0] != streamVersion {
+		return cr.n, fmt.Errorf("%w: unsupported version %d", ErrFormat, h[0])
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if h[1]&flagBigEndian != 0 {
+		order = binary.BigEndian
+	}
+	if got := binary.LittleEndian.Uint32(h[2:]); got != uint32(size) {
+		return cr.n, fmt.Errorf("%w: element size %d does not match %T of size %d", ErrFormat, got, zero, size)
+	}
+	height := binary.LittleEndian.Uint64(h[6:])
+	width := binary.LittleEndian.Uint64(h[14:])
+	if err := checkDataSize(height, width, size); err != nil {
+		return cr.n, err
+	}
+
+	colMajor := h[1]&flagColMajor != 0
+	slice, err := readElements[T](cr, order, int(height)*int(width), size)
+	if err != nil {
+		return cr.n, fmt.Errorf("array2d: reading elements: %w", err)
+	}
+	arr := newArray(int(height), int(width), slice, colMajor)
+	*a = arr
+	return cr.n, nil
+}
+
+// readChunkSize is the largest number of bytes of element data that
+// readElements allocates before reading them.
+const readChunkSize = 1 << 20
+
+// checkDataSize returns an error wrapping ErrFormat if an array of the given
+// dimensions and element size would hold more than maxInt bytes.
+func checkDataSize(height, width uint64, size int) error {
+	limit := uint64(maxInt) / uint64(size)
+	if height > limit || width > limit || (width != 0 && height > limit/width) {
+		return fmt.Errorf("%w: dimensions %dx%d are too large", ErrFormat, height, width)
+	}
+	return nil
+}
+
+// readElements reads n elements of size bytes each from r. Storage grows as
+// the data arrives, at most readChunkSize bytes at a time, so a header
+// claiming a huge array cannot force a large allocation that the data does
+// not back. If the data ends early, the error is io.ErrUnexpectedEOF.
+func readElements[T any](r io.Reader, order binary.ByteOrder, n, size int) ([]T, error) {
+	chunk := readChunkSize / size
+	if chunk == 0 {
+		chunk = 1
+	}
+	slice := make([]T, 0)
+	for len(slice) < n {
+		k := n - len(slice)
+		if k > chunk {
+			k = chunk
+		}
+		start := len(slice)
+		if cap(slice)-start < k {
+			grown := make([]T, start, growCap(cap(slice), start+k, n))
+			copy(grown, slice)
+			slice = grown
+		}
+		slice = slice[:start+k]
+		if err := binary.Read(r, order, slice[start:]); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+	}
+	return slice, nil
+}
+
+// growCap doubles capacity c until it holds need elements, without exceeding
+// limit.
+func growCap(c, need, limit int) int {
+	for c < need {
+		if c > limit/2 {
+			return limit
+		}
+		c *= 2
+		if c == 0 {
+			c = need
+		}
+	}
+	return c
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,102 @@
+//go:build go1.18
+// +build go1.18
+
+package array2d
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for _, colMajor := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%v/colMajor=%v", order, colMajor), func(t *testing.T) {
+				arr, _ := FromJagged(2, 3, [][]int32{{1, -2, 3}, {4, 5, 1 << 20}}, colMajor)
+				var buf bytes.Buffer
+				n, err := arr.WriteToOrder(&buf, order)
+				if err != nil {
+					t.Fatalf("WriteToOrder() returned an unexpected error: %v", err)
+				}
+				if want := int64(streamHeaderSize + 6*4); n != want || int64(buf.Len()) != want {
+					t.Errorf("want %d bytes written, got %d (buffer holds %d)", want, n, buf.Len())
+				}
+
+				var got Array2D[int32]
+				m, err := got.ReadFrom(&buf)
+				if err != nil {
+					t.Fatalf("ReadFrom() returned an unexpected error: %v", err)
+				}
+				if m != n {
+					t.Errorf("want %d bytes read, got %d", n, m)
+				}
+				if !Equal(arr, got) || got.colMajor != colMajor {
+					t.Errorf("want %v, got %v", arr, got)
+				}
+			})
+		}
+	}
+
+	t.Run("byte order", func(t *testing.T) {
+		arr, _ := FromSlice(1, 1, []uint16{0x0102})
+		var little, big bytes.Buffer
+		arr.WriteTo(&little)
+		arr.WriteToOrder(&big, binary.BigEndian)
+		if got := little.Bytes()[streamHeaderSize:]; !bytes.Equal(got, []byte{2, 1}) {
+			t.Errorf("little-endian: want [2 1], got %v", got)
+		}
+		if got := big.Bytes()[streamHeaderSize:]; !bytes.Equal(got, []byte{1, 2}) {
+			t.Errorf("big-endian: want [1 2], got %v", got)
+		}
+	})
+
+	t.Run("view", func(t *testing.T) {
+		arr, _ := FromJagged(3, 3, [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+		view := arr.view(1, 0, 2, 2)
+		var buf bytes.Buffer
+		view.WriteTo(&buf)
+		var got Array2D[float64]
+		if _, err := got.ReadFrom(&buf); err != nil || !Equal(view, got) {
+			t.Errorf("want %v, got %v (err=%v)", view, got, err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := New[string](1, 1).WriteTo(io.Discard); err == nil {
+			t.Errorf("want error for a variable-size element type")
+		}
+
+		var buf bytes.Buffer
+		NewFilled[int32](2, 2, 7).WriteTo(&buf)
+		data := buf.Bytes()
+
+		var wrongSize Array2D[int64]
+		if _, err := wrongSize.ReadFrom(bytes.NewReader(data)); !errors.Is(err, ErrFormat) {
+			t.Errorf("element size mismatch: want ErrFormat, got %v", err)
+		}
+		var got Array2D[int32]
+		if _, err := got.ReadFrom(bytes.NewReader(data[:10])); !errors.Is(err, ErrFormat) {
+			t.Errorf("short header: want ErrFormat, got %v", err)
+		}
+		if _, err := got.ReadFrom(bytes.NewReader(data[:len(data)-1])); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("truncated data: want io.ErrUnexpectedEOF, got %v", err)
+		}
+
+		huge := func(height, width uint64) []byte {
+			h := append([]byte(nil), data[:streamHeaderSize]...)
+			binary.LittleEndian.PutUint64(h[len(streamMagic)+6:], height)
+			binary.LittleEndian.PutUint64(h[len(streamMagic)+14:], width)
+			return h
+		}
+		if _, err := got.ReadFrom(bytes.NewReader(huge(1<<40, 1<<40))); !errors.Is(err, ErrFormat) {
+			t.Errorf("oversized dimensions: want ErrFormat, got %v", err)
+		}
+		if _, err := got.ReadFrom(bytes.NewReader(huge(1<<20, 1<<8))); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("huge array without data: want io.ErrUnexpectedEOF, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+//go:build go1.18
+// +build go1.18
+
+// Package xll converts Array2D values to and from the memory layouts used by
+// the Excel C SDK, for Excel add-ins (XLLs) written in Go.
+//
+// The functions in this package work on raw memory through unsafe.Pointer.
+// Pointers received from Excel are only valid for the duration of the call
+// that received them.
+//
+// # Memory handed to Excel
+//
+// Excel keeps using the memory of a returned FP12 or XLOPER12 after the
+// exported function has returned. NewFP12, ToFP12 and ToXLOPER12 therefore pin
+// every allocation behind the pointer they return with a runtime.Pinner, so
+// that it is neither moved nor freed and may be passed to C, and keep it
+// pinned until the pointer is passed to Free. Each returned pointer must be
+// freed exactly once, after Excel is done with it:
+//
+//   - ToXLOPER12 sets xlbitDLLFree on the result, so Excel calls the add-in's
+//     xlAutoFree12 with the pointer once it has copied the value. xlAutoFree12
+//     should pass it to Free.
+//   - Excel does not call back for FP12 results. It copies the array when the
+//     function returns, so the add-in can free the previous result at the
+//     start of the next call, or reuse one FP12 per function.
+//
+// For example:
+//
+//	//export xlAutoFree12
+//	func xlAutoFree12(p *C.XLOPER12) {
+//		xll.Free(unsafe.Pointer(p))
+//	}
+//
+// Memory that is never freed stays pinned for the life of the process.
+package xll
@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package xll
+
+import "strconv"
+
+// ErrorCode is an Excel error value, as stored in an xltypeErr XLOPER12.
+// It implements error, so that coercions of a Variant can report the Excel
+// error they produce.
+type ErrorCode int32
+
+// Excel error values, from xlcall.h.
+const (
+	ErrNull        ErrorCode = 0
+	ErrDiv0        ErrorCode = 7
+	ErrValue       ErrorCode = 15
+	ErrRef         ErrorCode = 23
+	ErrName        ErrorCode = 29
+	ErrNum         ErrorCode = 36
+	ErrNA          ErrorCode = 42
+	ErrGettingData ErrorCode = 43
+)
+
+// String returns the error as Excel displays it, such as "#N/A".
+func (e ErrorCode) String() string {
+	switch e {
+	case ErrNull:
+		return "#NULL!"
+	case ErrDiv0:
+		return "#DIV/0!"
+	case ErrValue:
+		return "#VALUE!"
+	case ErrRef:
+		return "#REF!"
+	case ErrName:
+		return "#NAME?"
+	case ErrNum:
+		return "#NUM!"
+	case ErrNA:
+		return "#N/A"
+	case ErrGettingData:
+		return "#GETTING_DATA"
+	}
+	return "ErrorCode(" + strconv.Itoa(int(e)) + ")"
+}
+
+// Error implements error and returns the same text as String.
+func (e ErrorCode) Error() string {
+	return e.String()
+}
@@ -0,0 +1,95 @@
+//go:build go1.21
+// +build go1.21
+
+package xll
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+
+	"github.com/xll-gen/array2d"
+)
+
+// fp12Header is the header of the Excel FP12 structure:
+//
+//	typedef struct _FP12 {
+//	    INT32 rows;
+//	    INT32 columns;
+//	    double array[1];
+//	} FP12;
+//
+// The doubles follow the header in row-major order.
+type fp12Header struct {
+	rows, columns int32
+}
+
+// Worksheet limits of Excel 2007 and later, which bound an FP12 and an
+// xltypeMulti.
+const (
+	maxRows = 1 << 20
+	maxCols = 1 << 14
+)
+
+// NewFP12 allocates an FP12 structure with the given dimensions and returns a
+// pointer to it, suitable for returning to Excel, together with a row-major
+// array that shares its storage. Writes to the array fill the FP12 in place,
+// so no copy is needed before handing the pointer to Excel.
+//
+// The memory is pinned until the pointer is passed to Free; see the package
+// documentation.
+//
+// It returns an error wrapping array2d.ErrShape if a dimension is negative or
+// larger than a worksheet (1048576 rows by 16384 columns), or if the FP12
+// would not fit in memory addressable on this platform.
+func NewFP12(rows, cols int) (unsafe.Pointer, array2d.Array2D[float64], error) {
+	if rows < 0 || cols < 0 || rows > maxRows || cols > maxCols {
+		return nil, array2d.Array2D[float64]{}, fmt.Errorf("%w: invalid FP12 dimensions %dx%d", array2d.ErrShape, rows, cols)
+	}
+	if cols != 0 && rows > (math.MaxInt/8-1)/cols {
+		return nil, array2d.Array2D[float64]{}, fmt.Errorf("%w: %dx%d FP12 is too large", array2d.ErrShape, rows, cols)
+	}
+	// The header occupies exactly one float64 slot, so a single allocation
+	// holds both it and the correctly aligned data.
+	buf := make([]float64, 1+rows*cols)
+	p := unsafe.Pointer(&buf[0])
+	*(*fp12Header)(p) = fp12Header{rows: int32(rows), columns: int32(cols)}
+	var pn runtime.Pinner
+	pn.Pin(p)
+	register(p, &pn)
+	arr, _ := array2d.FromSlice(rows, cols, buf[1:])
+	return p, arr, nil
+}
+
+// ToFP12 returns a pointer to a newly allocated FP12 structure holding a copy
+// of a. a may use either memory layout. As with NewFP12, the pointer must be
+// passed to Free once Excel is done with it.
+func ToFP12(a array2d.Array2D[float64]) (unsafe.Pointer, error) {
+	p, arr, err := NewFP12(a.Height(), a.Width())
+	if err != nil {
+		return nil, err
+	}
+	_ = arr.CopyFrom(a)
+	return p, nil
+}
+
+// FromFP12 returns a row-major array that views the data of the FP12 structure
+// at p without copying it: changes made through the array are visible to
+// Excel and vice versa. A nil pointer yields an empty array.
+//
+// The array is only valid while the memory at p is, which for arguments
+// passed by Excel is the duration of the call. Use Copy to keep the data.
+func FromFP12(p unsafe.Pointer) array2d.Array2D[float64] {
+	if p == nil {
+		return array2d.New[float64](0, 0)
+	}
+	h := (*fp12Header)(p)
+	rows, cols := int(h.rows), int(h.columns)
+	if rows <= 0 || cols <= 0 {
+		return array2d.New[float64](0, 0)
+	}
+	data := unsafe.Slice((*float64)(unsafe.Add(p, unsafe.Sizeof(*h))), rows*cols)
+	arr, _ := array2d.FromSlice(rows, cols, data)
+	return arr
+}
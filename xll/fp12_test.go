@@ -0,0 +1,75 @@
+//go:build go1.21
+// +build go1.21
+
+package xll
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"unsafe"
+
+	"github.com/xll-gen/array2d"
+)
+
+func TestFP12(t *testing.T) {
+	src, _ := array2d.FromJagged(2, 3, [][]float64{{1, 2, 3}, {4, 5, 6}}, true)
+	p, err := ToFP12(src)
+	if err != nil {
+		t.Fatalf("ToFP12() returned an unexpected error: %v", err)
+	}
+
+	h := (*fp12Header)(p)
+	if h.rows != 2 || h.columns != 3 {
+		t.Errorf("header: want 2x3, got %dx%d", h.rows, h.columns)
+	}
+	data := unsafe.Slice((*float64)(unsafe.Add(p, 8)), 6)
+	if want := []float64{1, 2, 3, 4, 5, 6}; !equalFloats(data, want) {
+		t.Errorf("data: want row-major %v, got %v", want, data)
+	}
+
+	view := FromFP12(p)
+	if !array2d.Equal(src, view) {
+		t.Errorf("FromFP12: want %v, got %v", src, view)
+	}
+	view.Set(1, 2, 60)
+	if data[5] != 60 {
+		t.Errorf("FromFP12 does not share memory with the FP12")
+	}
+
+	q, arr, _ := NewFP12(1, 2)
+	arr.Set(0, 1, 7)
+	if got := FromFP12(q).String(); got != "Array2d[float64] 1x2 [[0 7]]" {
+		t.Errorf("NewFP12: array does not fill the FP12 in place, got %q", got)
+	}
+
+	for _, ptr := range []unsafe.Pointer{p, q} {
+		if !Free(ptr) {
+			t.Errorf("Free(): want true for a pointer returned by ToFP12 or NewFP12")
+		}
+	}
+	if Free(p) {
+		t.Errorf("Free(): want false for a pointer freed twice")
+	}
+
+	if got := FromFP12(nil); got.Height() != 0 || got.Width() != 0 {
+		t.Errorf("FromFP12(nil): want empty array, got %v", got)
+	}
+	for _, dims := range [][2]int{{-1, 2}, {maxRows + 1, 1}, {1, maxCols + 1}, {math.MaxInt32, math.MaxInt32}} {
+		if _, _, err := NewFP12(dims[0], dims[1]); !errors.Is(err, array2d.ErrShape) {
+			t.Errorf("NewFP12(%d, %d): want ErrShape, got %v", dims[0], dims[1], err)
+		}
+	}
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+//go:build go1.21
+// +build go1.21
+
+package xll
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// pinned maps each pointer handed out by NewFP12, ToFP12 and ToXLOPER12 to the
+// Pinner holding the memory behind it, until the pointer is passed to Free.
+var pinned = struct {
+	sync.Mutex
+	m map[unsafe.Pointer]*runtime.Pinner
+}{m: make(map[unsafe.Pointer]*runtime.Pinner)}
+
+// register records that p and the memory it refers to are pinned by pn.
+func register(p unsafe.Pointer, pn *runtime.Pinner) {
+	pinned.Lock()
+	pinned.m[p] = pn
+	pinned.Unlock()
+}
+
+// Free unpins the memory behind a pointer returned by NewFP12, ToFP12 or
+// ToXLOPER12, letting the garbage collector reclaim it. Excel must no longer
+// use the memory, and neither may arrays returned by NewFP12 for it.
+//
+// Free reports whether p was returned by this package and not yet freed;
+// other pointers are ignored, so it is safe to call from xlAutoFree12 for any
+// XLOPER12 Excel passes back.
+func Free(p unsafe.Pointer) bool {
+	pinned.Lock()
+	pn, ok := pinned.m[p]
+	delete(pinned.m, p)
+	pinned.Unlock()
+	if ok {
+		pn.Unpin()
+	}
+	return ok
+}
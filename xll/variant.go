@@ -0,0 +1,305 @@
+//go:build go1.18
+// +build go1.18
+
+package xll
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/xll-gen/array2d"
+)
+
+// Kind is the type of value held by a Variant.
+type Kind uint8
+
+// The kinds of worksheet cell values.
+const (
+	KindEmpty Kind = iota
+	KindNumber
+	KindString
+	KindBool
+	KindError
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindEmpty:
+		return "empty"
+	case KindNumber:
+		return "number"
+	case KindString:
+		return "string"
+	case KindBool:
+		return "bool"
+	case KindError:
+		return "error"
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Variant is the value of a worksheet cell: empty, a number, a string, a
+// boolean or an Excel error such as #N/A. The zero Variant is empty.
+//
+// The As methods convert a Variant to a Go type following the coercion rules
+// Excel applies to function arguments. A Variant holding an error yields that
+// ErrorCode from every coercion, and a value that cannot be converted yields
+// ErrValue.
+type Variant struct {
+	kind Kind
+	num  float64
+	str  string
+	b    bool
+	err  ErrorCode
+}
+
+// NewNumber returns a Variant holding the number f. A worksheet cannot hold
+// NaN or infinities, so for those it returns the #NUM! error instead.
+func NewNumber(f float64) Variant {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return NewError(ErrNum)
+	}
+	return Variant{kind: KindNumber, num: f}
+}
+
+// NewString returns a Variant holding the string s.
+func NewString(s string) Variant {
+	return Variant{kind: KindString, str: s}
+}
+
+// NewBool returns a Variant holding the boolean b.
+func NewBool(b bool) Variant {
+	return Variant{kind: KindBool, b: b}
+}
+
+// NewError returns a Variant holding the Excel error e.
+func NewError(e ErrorCode) Variant {
+	return Variant{kind: KindError, err: e}
+}
+
+// VariantOf converts a Go value to a Variant: nil becomes empty, integer and
+// floating-point values become numbers as by NewNumber, and string, bool and
+// ErrorCode values become the corresponding kinds. A Variant is returned
+// unchanged. Values of any other type become ErrValue.
+func VariantOf(v any) Variant {
+	switch v := v.(type) {
+	case nil:
+		return Variant{}
+	case Variant:
+		return v
+	case string:
+		return NewString(v)
+	case bool:
+		return NewBool(v)
+	case ErrorCode:
+		return NewError(v)
+	}
+	if f, ok := toFloat(v); ok {
+		return NewNumber(f)
+	}
+	return NewError(ErrValue)
+}
+
+// Kind returns the kind of value held by v.
+func (v Variant) Kind() Kind {
+	return v.kind
+}
+
+// IsEmpty reports whether v is an empty cell.
+func (v Variant) IsEmpty() bool {
+	return v.kind == KindEmpty
+}
+
+// Any returns the value held by v as a Go value: nil, float64, string, bool or
+// ErrorCode. It is the inverse of VariantOf and produces the values expected
+// by ToXLOPER12.
+func (v Variant) Any() any {
+	switch v.kind {
+	case KindNumber:
+		return v.num
+	case KindString:
+		return v.str
+	case KindBool:
+		return v.b
+	case KindError:
+		return v.err
+	}
+	return nil
+}
+
+// AsNumber coerces v to a number. Empty cells are 0, booleans 1 or 0, and
+// strings are parsed as decimal numbers, ignoring surrounding spaces. Strings
+// such as "NaN", "Inf" or "0x1p3" and numbers too large for a float64 are not
+// numbers to Excel and yield ErrValue.
+func (v Variant) AsNumber() (float64, error) {
+	switch v.kind {
+	case KindEmpty:
+		return 0, nil
+	case KindNumber:
+		return v.num, nil
+	case KindBool:
+		if v.b {
+			return 1, nil
+		}
+		return 0, nil
+	case KindString:
+		return parseNumber(v.str)
+	}
+	return 0, v.err
+}
+
+// parseNumber parses a decimal number the way Excel coerces text, ignoring
+// surrounding spaces. Unlike strconv.ParseFloat it rejects "NaN", "Inf",
+// hexadecimal and underscore-separated forms, which Excel does not accept.
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.Trim(s, "0123456789.eE+-") != "" {
+		return 0, ErrValue
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, ErrValue
+	}
+	return f, nil
+}
+
+// AsString coerces v to a string. Empty cells are "", numbers are formatted in
+// the shortest form that round-trips, and booleans are "TRUE" or "FALSE".
+func (v Variant) AsString() (string, error) {
+	if v.kind == KindError {
+		return "", v.err
+	}
+	return v.String(), nil
+}
+
+// AsBool coerces v to a boolean. Empty cells are false, numbers are true if
+// they are not zero, and the strings "TRUE" and "FALSE" are accepted in any
+// case; other strings cannot be converted.
+func (v Variant) AsBool() (bool, error) {
+	switch v.kind {
+	case KindEmpty:
+		return false, nil
+	case KindNumber:
+		return v.num != 0, nil
+	case KindBool:
+		return v.b, nil
+	case KindString:
+		switch {
+		case strings.EqualFold(v.str, "TRUE"):
+			return true, nil
+		case strings.EqualFold(v.str, "FALSE"):
+			return false, nil
+		}
+		return false, ErrValue
+	}
+	return false, v.err
+}
+
+// String returns v as a worksheet would display it with the General format.
+func (v Variant) String() string {
+	switch v.kind {
+	case KindNumber:
+		return strconv.FormatFloat(v.num, 'g', -1, 64)
+	case KindString:
+		return v.str
+	case KindBool:
+		if v.b {
+			return "TRUE"
+		}
+		return "FALSE"
+	case KindError:
+		return v.err.String()
+	}
+	return ""
+}
+
+// toFloat converts any Go integer or floating-point value to float64.
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Range is an array of worksheet cell values with typed accessors that apply
+// the coercion rules of Variant.
+type Range struct {
+	array2d.Array2D[Variant]
+}
+
+// NewRange converts an array of Go values, such as one returned by
+// FromXLOPER12, into a Range using VariantOf.
+func NewRange(a array2d.Array2D[any]) Range {
+	return Range{array2d.Map(a, VariantOf)}
+}
+
+// ToAny converts the range into an array of Go values, as accepted by
+// ToXLOPER12.
+func (r Range) ToAny() array2d.Array2D[any] {
+	return array2d.Map(r.Array2D, Variant.Any)
+}
+
+// GetNumber returns the cell at (row, col) coerced to a number. It returns an
+// error wrapping array2d.ErrOutOfBounds if the position is out of bounds, and
+// the ErrorCode produced by the coercion if it fails.
+func (r Range) GetNumber(row, col int) (float64, error) {
+	v, err := r.cell(row, col)
+	if err != nil {
+		return 0, err
+	}
+	return v.AsNumber()
+}
+
+// GetString returns the cell at (row, col) coerced to a string, with the same
+// errors as GetNumber.
+func (r Range) GetString(row, col int) (string, error) {
+	v, err := r.cell(row, col)
+	if err != nil {
+		return "", err
+	}
+	return v.AsString()
+}
+
+// GetBool returns the cell at (row, col) coerced to a boolean, with the same
+// errors as GetNumber.
+func (r Range) GetBool(row, col int) (bool, error) {
+	v, err := r.cell(row, col)
+	if err != nil {
+		return false, err
+	}
+	return v.AsBool()
+}
+
+func (r Range) cell(row, col int) (Variant, error) {
+	v, ok := r.Get(row, col)
+	if !ok {
+		return Variant{}, fmt.Errorf("%w: (%d, %d) outside %dx%d range", array2d.ErrOutOfBounds, row, col, r.Height(), r.Width())
+	}
+	return v, nil
+}
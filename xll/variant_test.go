@@ -0,0 +1,103 @@
+//go:build go1.18
+// +build go1.18
+
+package xll
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/xll-gen/array2d"
+)
+
+func TestVariantCoercion(t *testing.T) {
+	tests := []struct {
+		v       Variant
+		num     float64
+		numErr  error
+		str     string
+		strErr  error
+		b       bool
+		boolErr error
+	}{
+		{v: Variant{}, num: 0, str: "", b: false},
+		{v: NewNumber(2.5), num: 2.5, str: "2.5", b: true},
+		{v: NewNumber(0), num: 0, str: "0", b: false},
+		{v: NewString(" 12 "), num: 12, str: " 12 ", boolErr: ErrValue},
+		{v: NewString("true"), numErr: ErrValue, str: "true", b: true},
+		{v: NewString("-1.5e3"), num: -1500, str: "-1.5e3", boolErr: ErrValue},
+		{v: NewString("NaN"), numErr: ErrValue, str: "NaN", boolErr: ErrValue},
+		{v: NewString("Inf"), numErr: ErrValue, str: "Inf", boolErr: ErrValue},
+		{v: NewString("-Infinity"), numErr: ErrValue, str: "-Infinity", boolErr: ErrValue},
+		{v: NewString("0x1p3"), numErr: ErrValue, str: "0x1p3", boolErr: ErrValue},
+		{v: NewString("1_000"), numErr: ErrValue, str: "1_000", boolErr: ErrValue},
+		{v: NewString("1e400"), numErr: ErrValue, str: "1e400", boolErr: ErrValue},
+		{v: NewNumber(math.Inf(1)), numErr: ErrNum, strErr: ErrNum, boolErr: ErrNum},
+		{v: NewBool(true), num: 1, str: "TRUE", b: true},
+		{v: NewError(ErrNA), numErr: ErrNA, strErr: ErrNA, boolErr: ErrNA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v.Kind().String()+":"+tt.v.String(), func(t *testing.T) {
+			if num, err := tt.v.AsNumber(); num != tt.num || err != tt.numErr {
+				t.Errorf("AsNumber(): want %v, %v, got %v, %v", tt.num, tt.numErr, num, err)
+			}
+			if str, err := tt.v.AsString(); str != tt.str || err != tt.strErr {
+				t.Errorf("AsString(): want %q, %v, got %q, %v", tt.str, tt.strErr, str, err)
+			}
+			if b, err := tt.v.AsBool(); b != tt.b || err != tt.boolErr {
+				t.Errorf("AsBool(): want %v, %v, got %v, %v", tt.b, tt.boolErr, b, err)
+			}
+		})
+	}
+}
+
+func TestVariantOf(t *testing.T) {
+	tests := []struct {
+		in   any
+		want Variant
+	}{
+		{nil, Variant{}},
+		{int16(3), NewNumber(3)},
+		{math.NaN(), NewError(ErrNum)},
+		{"x", NewString("x")},
+		{false, NewBool(false)},
+		{ErrDiv0, NewError(ErrDiv0)},
+		{NewNumber(1), NewNumber(1)},
+		{struct{}{}, NewError(ErrValue)},
+	}
+	for _, tt := range tests {
+		if got := VariantOf(tt.in); got != tt.want {
+			t.Errorf("VariantOf(%#v): want %v, got %v", tt.in, tt.want, got)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	src, _ := array2d.FromJagged(2, 2, [][]any{
+		{1.5, "2"},
+		{nil, ErrRef},
+	})
+	r := NewRange(src)
+
+	if got, err := r.GetNumber(0, 1); err != nil || got != 2 {
+		t.Errorf("GetNumber(0, 1): want 2, got %v, %v", got, err)
+	}
+	if got, err := r.GetString(0, 0); err != nil || got != "1.5" {
+		t.Errorf("GetString(0, 0): want %q, got %q, %v", "1.5", got, err)
+	}
+	if got, err := r.GetBool(1, 0); err != nil || got {
+		t.Errorf("GetBool(1, 0): want false, got %v, %v", got, err)
+	}
+	var code ErrorCode
+	if _, err := r.GetNumber(1, 1); !errors.As(err, &code) || code != ErrRef {
+		t.Errorf("GetNumber(1, 1): want %v, got %v", ErrRef, err)
+	}
+	if _, err := r.GetNumber(2, 0); !errors.Is(err, array2d.ErrOutOfBounds) {
+		t.Errorf("want error to be ErrOutOfBounds, but it was not. got: %v", err)
+	}
+
+	if got := r.ToAny(); !array2d.Equal(got, src) {
+		t.Errorf("ToAny(): want %v, got %v", src, got)
+	}
+}
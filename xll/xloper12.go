@@ -0,0 +1,215 @@
+//go:build go1.21 && (amd64 || arm64)
+// +build go1.21
+// +build amd64 arm64
+
+package xll
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/xll-gen/array2d"
+)
+
+// XLOPER12 type codes, from xlcall.h.
+const (
+	xltypeNum     = 0x0001
+	xltypeStr     = 0x0002
+	xltypeBool    = 0x0004
+	xltypeErr     = 0x0010
+	xltypeMulti   = 0x0040
+	xltypeMissing = 0x0080
+	xltypeNil     = 0x0100
+	xltypeInt     = 0x0800
+
+	// xlbitXLFree and xlbitDLLFree mark who frees an XLOPER12's memory and
+	// are ignored when reading values.
+	xlbitXLFree  = 0x1000
+	xlbitDLLFree = 0x4000
+)
+
+// maxStrLen is the longest string an XLOPER12 can hold, in UTF-16 code units.
+const maxStrLen = 32767
+
+// XLOPER12 has the memory layout of the Excel SDK's XLOPER12 structure on
+// 64-bit Windows: a 24-byte value union followed by the xltype field.
+// Pointers to XLOPER12 values received from Excel can be converted to
+// *XLOPER12 and read with FromXLOPER12.
+type XLOPER12 struct {
+	val    [3]uint64
+	xltype uint32
+	_      uint32
+}
+
+// Type returns the xltype of the value, without the memory management bits.
+func (x *XLOPER12) Type() uint32 {
+	return x.xltype &^ (xlbitXLFree | xlbitDLLFree)
+}
+
+// ptr returns a pointer to the pointer-sized field at the start of the value
+// union, which holds the string or array pointer.
+func (x *XLOPER12) ptr() *unsafe.Pointer {
+	return (*unsafe.Pointer)(unsafe.Pointer(&x.val[0]))
+}
+
+// int32At returns a pointer to the 32-bit integer at the given byte offset of
+// the value union.
+func (x *XLOPER12) int32At(offset uintptr) *int32 {
+	return (*int32)(unsafe.Add(unsafe.Pointer(&x.val[0]), offset))
+}
+
+// ToXLOPER12 converts a into an xltypeMulti XLOPER12 whose cells are in
+// row-major order, as Excel expects. Cell values are converted as follows:
+//
+//   - nil becomes xltypeNil (an empty cell)
+//   - integer and floating-point values become xltypeNum, except NaN and
+//     infinities, which become the #NUM! error
+//   - strings become xltypeStr
+//   - bools become xltypeBool
+//   - ErrorCode values become xltypeErr
+//
+// The result has xlbitDLLFree set and its memory is pinned until it is passed
+// to Free, which the add-in's xlAutoFree12 should do; see the package
+// documentation.
+//
+// It returns an error wrapping array2d.ErrShape if a is empty or larger than a
+// worksheet, and an error for values of any other type or strings longer than
+// 32767 UTF-16 code units.
+func ToXLOPER12(a array2d.Array2D[any]) (*XLOPER12, error) {
+	rows, cols := a.Height(), a.Width()
+	if rows == 0 || cols == 0 || rows > maxRows || cols > maxCols {
+		return nil, fmt.Errorf("%w: invalid xltypeMulti dimensions %dx%d", array2d.ErrShape, rows, cols)
+	}
+	// The cells and strings are referenced only through pointers stored in
+	// the value unions, which the garbage collector does not see, so each
+	// allocation is pinned before its address is stored.
+	pn := new(runtime.Pinner)
+	cells := make([]XLOPER12, rows*cols)
+	pn.Pin(&cells[0])
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			v, _ := a.Get(r, c)
+			if err := setCell(&cells[r*cols+c], v, pn); err != nil {
+				pn.Unpin()
+				return nil, fmt.Errorf("xll: cell (%d, %d): %w", r, c, err)
+			}
+		}
+	}
+	x := new(XLOPER12)
+	pn.Pin(x)
+	x.xltype = xltypeMulti | xlbitDLLFree
+	*x.ptr() = unsafe.Pointer(&cells[0])
+	*x.int32At(8) = int32(rows)
+	*x.int32At(12) = int32(cols)
+	register(unsafe.Pointer(x), pn)
+	return x, nil
+}
+
+// setCell stores v in the XLOPER12 at x, pinning any memory it allocates with
+// pn.
+func setCell(x *XLOPER12, v any, pn *runtime.Pinner) error {
+	switch v := v.(type) {
+	case nil:
+		x.xltype = xltypeNil
+	case string:
+		s := utf16.Encode([]rune(v))
+		if len(s) > maxStrLen {
+			return fmt.Errorf("string of %d UTF-16 code units exceeds %d", len(s), maxStrLen)
+		}
+		// Excel strings are length-prefixed rather than null-terminated.
+		buf := make([]uint16, 1+len(s))
+		buf[0] = uint16(len(s))
+		copy(buf[1:], s)
+		pn.Pin(&buf[0])
+		x.xltype = xltypeStr
+		*x.ptr() = unsafe.Pointer(&buf[0])
+	case bool:
+		x.xltype = xltypeBool
+		if v {
+			*x.int32At(0) = 1
+		}
+	case ErrorCode:
+		x.xltype = xltypeErr
+		*x.int32At(0) = int32(v)
+	default:
+		f, ok := toFloat(v)
+		if !ok {
+			return fmt.Errorf("unsupported value type %T", v)
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			x.xltype = xltypeErr
+			*x.int32At(0) = int32(ErrNum)
+			return nil
+		}
+		x.xltype = xltypeNum
+		x.val[0] = math.Float64bits(f)
+	}
+	return nil
+}
+
+// FromXLOPER12 converts the XLOPER12 at x into an array. An xltypeMulti yields
+// an array of its dimensions and any other value a 1x1 array. Cell values are
+// converted as follows:
+//
+//   - xltypeNil and xltypeMissing become nil
+//   - xltypeNum and xltypeInt become float64
+//   - xltypeStr becomes string
+//   - xltypeBool becomes bool
+//   - xltypeErr becomes ErrorCode
+//
+// Because the cells are converted to Go values, the result never shares memory
+// with x. It returns an error for references and other types that do not hold
+// a value.
+func FromXLOPER12(x *XLOPER12) (array2d.Array2D[any], error) {
+	if x.Type() != xltypeMulti {
+		v, err := cellValue(x)
+		if err != nil {
+			return array2d.Array2D[any]{}, err
+		}
+		arr := array2d.New[any](1, 1)
+		_ = arr.Set(0, 0, v)
+		return arr, nil
+	}
+	rows, cols := int(*x.int32At(8)), int(*x.int32At(12))
+	if rows <= 0 || cols <= 0 || *x.ptr() == nil {
+		return array2d.New[any](0, 0), nil
+	}
+	cells := unsafe.Slice((*XLOPER12)(*x.ptr()), rows*cols)
+	values := make([]any, len(cells))
+	for i := range cells {
+		v, err := cellValue(&cells[i])
+		if err != nil {
+			return array2d.Array2D[any]{}, fmt.Errorf("xll: cell (%d, %d): %w", i/cols, i%cols, err)
+		}
+		values[i] = v
+	}
+	return array2d.FromSlice(rows, cols, values)
+}
+
+func cellValue(x *XLOPER12) (any, error) {
+	switch t := x.Type(); t {
+	case xltypeNil, xltypeMissing:
+		return nil, nil
+	case xltypeNum:
+		return math.Float64frombits(x.val[0]), nil
+	case xltypeInt:
+		return float64(*x.int32At(0)), nil
+	case xltypeStr:
+		p := (*uint16)(*x.ptr())
+		if p == nil {
+			return "", nil
+		}
+		n := int(*p)
+		s := unsafe.Slice((*uint16)(unsafe.Add(unsafe.Pointer(p), 2)), n)
+		return string(utf16.Decode(s)), nil
+	case xltypeBool:
+		return *x.int32At(0) != 0, nil
+	case xltypeErr:
+		return ErrorCode(*x.int32At(0)), nil
+	default:
+		return nil, fmt.Errorf("unsupported xltype 0x%x", t)
+	}
+}
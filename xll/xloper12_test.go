@@ -0,0 +1,107 @@
+//go:build go1.21 && (amd64 || arm64)
+// +build go1.21
+// +build amd64 arm64
+
+package xll
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/xll-gen/array2d"
+)
+
+func TestXLOPER12Layout(t *testing.T) {
+	var x XLOPER12
+	if size := unsafe.Sizeof(x); size != 32 {
+		t.Errorf("want XLOPER12 of 32 bytes, got %d", size)
+	}
+	if offset := unsafe.Offsetof(x.xltype); offset != 24 {
+		t.Errorf("want xltype at offset 24, got %d", offset)
+	}
+}
+
+func TestXLOPER12(t *testing.T) {
+	src, _ := array2d.FromJagged(2, 3, [][]any{
+		{1.5, "héllo, 世界", true},
+		{nil, 42, ErrNA},
+	}, true)
+	x, err := ToXLOPER12(src)
+	if err != nil {
+		t.Fatalf("ToXLOPER12() returned an unexpected error: %v", err)
+	}
+	// Only pinning keeps the cells and strings alive, since x refers to them
+	// through integer fields.
+	runtime.GC()
+
+	if x.Type() != xltypeMulti || x.xltype&xlbitDLLFree == 0 {
+		t.Errorf("want xltypeMulti with xlbitDLLFree, got 0x%x", x.xltype)
+	}
+	cells := unsafe.Slice((*XLOPER12)(*x.ptr()), 6)
+	if types := []uint32{cells[1].Type(), cells[3].Type(), cells[5].Type()}; types[0] != xltypeStr || types[1] != xltypeNil || types[2] != xltypeErr {
+		t.Errorf("cells are not in row-major order: types %x", types)
+	}
+
+	got, err := FromXLOPER12(x)
+	if err != nil {
+		t.Fatalf("FromXLOPER12() returned an unexpected error: %v", err)
+	}
+	want := "Array2d[interface {}] 2x3 [[1.5 héllo, 世界 true] [<nil> 42 #N/A]]"
+	if got.String() != want {
+		t.Errorf("want %q, got %q", want, got.String())
+	}
+	if v, _ := got.Get(1, 1); v != 42.0 {
+		t.Errorf("want numbers as float64, got %T", v)
+	}
+
+	t.Run("scalar", func(t *testing.T) {
+		s := cells[1]
+		s.xltype |= xlbitXLFree
+		got, err := FromXLOPER12(&s)
+		if err != nil || got.String() != "Array2d[interface {}] 1x1 [[héllo, 世界]]" {
+			t.Errorf("want 1x1 string array, got %v (err=%v)", got, err)
+		}
+	})
+
+	if !Free(unsafe.Pointer(x)) {
+		t.Errorf("Free(): want true for a pointer returned by ToXLOPER12")
+	}
+	if Free(unsafe.Pointer(x)) {
+		t.Errorf("Free(): want false for a pointer freed twice")
+	}
+
+	t.Run("non-finite", func(t *testing.T) {
+		src, _ := array2d.FromSlice(1, 2, []any{math.NaN(), math.Inf(-1)})
+		x, err := ToXLOPER12(src)
+		if err != nil {
+			t.Fatalf("ToXLOPER12() returned an unexpected error: %v", err)
+		}
+		defer Free(unsafe.Pointer(x))
+		got, _ := FromXLOPER12(x)
+		if want := "Array2d[interface {}] 1x2 [[#NUM! #NUM!]]"; got.String() != want {
+			t.Errorf("want %q, got %q", want, got.String())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := ToXLOPER12(array2d.New[any](0, 1)); !errors.Is(err, array2d.ErrShape) {
+			t.Errorf("empty array: want ErrShape, got %v", err)
+		}
+		bad, _ := array2d.FromSlice(1, 1, []any{struct{}{}})
+		if _, err := ToXLOPER12(bad); err == nil {
+			t.Errorf("want error for an unsupported value type")
+		}
+		long, _ := array2d.FromSlice(1, 1, []any{strings.Repeat("x", maxStrLen+1)})
+		if _, err := ToXLOPER12(long); err == nil {
+			t.Errorf("want error for a string that is too long")
+		}
+		ref := XLOPER12{xltype: 0x0008}
+		if _, err := FromXLOPER12(&ref); err == nil {
+			t.Errorf("want error for xltypeRef")
+		}
+	})
+}
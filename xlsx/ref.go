@@ -0,0 +1,34 @@
+//go:build go1.18
+// +build go1.18
+
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/xll-gen/array2d"
+)
+
+// maxRows and maxCols are the worksheet limits of Excel 2007 and later.
+const (
+	maxRows = 1 << 20
+	maxCols = 1 << 14
+)
+
+// parseCell converts an A1-style reference such as "B3" into 0-based row and
+// column indices, rejecting cells outside the worksheet limits.
+func parseCell(ref string) (row, col int, err error) {
+	row, col, err = array2d.ParseA1(ref)
+	if err != nil {
+		return 0, 0, fmt.Errorf("xlsx: %w", err)
+	}
+	if row >= maxRows || col >= maxCols {
+		return 0, 0, fmt.Errorf("xlsx: cell reference %q outside the worksheet", ref)
+	}
+	return row, col, nil
+}
+
+// cellName returns the A1-style reference for 0-based row and column indices.
+func cellName(row, col int) string {
+	return array2d.FormatA1(row, col)
+}
@@ -0,0 +1,528 @@
+//go:build go1.18
+// +build go1.18
+
+// Package xlsx reads and writes Array2D values as ranges of Excel .xlsx
+// worksheets.
+//
+// It implements the small part of the Office Open XML spreadsheet format that
+// is needed to move cell values in and out of a workbook, using only the
+// standard library. Formatting, formulas and other workbook features are not
+// supported: a formula cell is read as its cached value.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xll-gen/array2d"
+)
+
+// DefaultMaxCells is the largest range FromSheet reads unless the caller
+// passes another limit: 1<<24 cells, a 4096x4096 range.
+const DefaultMaxCells = 1 << 24
+
+// ToSheet writes a workbook containing a single worksheet named sheet to w,
+// with the contents of a placed in the range whose top-left cell is origin, an
+// A1-style reference such as "B3". An empty origin means "A1" and an empty
+// sheet name means "Sheet1". Worksheet names are limited to 31 characters
+// and may not contain any of []:*?/\.
+//
+// Values are stored by their dynamic type: numbers as numeric cells, booleans
+// as boolean cells and strings as text. Empty cells are preserved by not
+// writing nil values or empty strings at all. NaN and infinities, which a
+// worksheet cannot hold, become #NUM! error cells, and values of any other
+// type are written as text formatted with fmt.Sprint.
+func ToSheet[T any](w io.Writer, a array2d.Array2D[T], sheet, origin string) error {
+	if sheet == "" {
+		sheet = "Sheet1"
+	}
+	if len(sheet) > 31 || strings.ContainsAny(sheet, `[]:*?/\`) {
+		return fmt.Errorf("xlsx: invalid worksheet name %q", sheet)
+	}
+	top, left := 0, 0
+	if origin != "" {
+		var err error
+		if top, left, err = parseCell(origin); err != nil {
+			return err
+		}
+	}
+	if top+a.Height() > maxRows || left+a.Width() > maxCols {
+		return fmt.Errorf("%w: %dx%d range at %s exceeds the worksheet", array2d.ErrOutOfBounds, a.Height(), a.Width(), cellName(top, left))
+	}
+
+	zw := zip.NewWriter(w)
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, escape(sheet))},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+	} {
+		if err := writePart(zw, part.name, part.body); err != nil {
+			return err
+		}
+	}
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if err := writeSheet(f, a, top, left); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeSheet writes the worksheet XML for a placed at (top, left).
+func writeSheet[T any](w io.Writer, a array2d.Array2D[T], top, left int) error {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if a.Height() > 0 && a.Width() > 0 {
+		fmt.Fprintf(&sb, `<dimension ref="%s:%s"/>`, cellName(top, left), cellName(top+a.Height()-1, left+a.Width()-1))
+	}
+	sb.WriteString(`<sheetData>`)
+	for r := 0; r < a.Height(); r++ {
+		fmt.Fprintf(&sb, `<row r="%d">`, top+r+1)
+		for c := 0; c < a.Width(); c++ {
+			v, _ := a.Get(r, c)
+			writeCell(&sb, cellName(top+r, left+c), v)
+		}
+		sb.WriteString(`</row>`)
+		if sb.Len() > 1<<16 {
+			if _, err := io.WriteString(w, sb.String()); err != nil {
+				return err
+			}
+			sb.Reset()
+		}
+	}
+	sb.WriteString(`</sheetData></worksheet>`)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeCell appends the XML for a cell holding v, or nothing if v is empty.
+func writeCell(sb *strings.Builder, ref string, v any) {
+	if v == nil {
+		return
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		b := "0"
+		if rv.Bool() {
+			b = "1"
+		}
+		fmt.Fprintf(sb, `<c r="%s" t="b"><v>%s</v></c>`, ref, b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(sb, `<c r="%s"><v>%d</v></c>`, ref, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprintf(sb, `<c r="%s"><v>%d</v></c>`, ref, rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			fmt.Fprintf(sb, `<c r="%s" t="e"><v>#NUM!</v></c>`, ref)
+			return
+		}
+		fmt.Fprintf(sb, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(f, 'g', -1, rv.Type().Bits()))
+	case reflect.String:
+		if rv.Len() == 0 {
+			return
+		}
+		fmt.Fprintf(sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escape(rv.String()))
+	default:
+		fmt.Fprintf(sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escape(fmt.Sprint(v)))
+	}
+}
+
+func writePart(zw *zip.Writer, name, body string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, body)
+	return err
+}
+
+func escape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// FromSheet reads a range of cells from the worksheet named sheet of the
+// workbook in r, which holds size bytes. An empty sheet name selects the
+// first worksheet.
+//
+// ref selects the range, either as "B2:D5" or as a single top-left cell such
+// as "B2", in which case the range extends to the last used row and column of
+// the worksheet; an empty ref means "A1". Cell (0, 0) of the result is the
+// top-left cell of the range.
+//
+// Empty cells are nil. Numeric cells are float64 values, boolean cells bool
+// values, and text and error cells (such as "#N/A") string values. Date cells,
+// which Excel itself stores as numbers but other writers may store as ISO 8601
+// text, are string values holding that text. Formula cells hold their cached
+// result.
+//
+// Because the result is dense, a single cell far from the origin makes it
+// large. maxCells optionally limits the number of cells in the range, which
+// defaults to DefaultMaxCells; a larger range is an error wrapping
+// array2d.ErrShape, returned before anything is allocated for it. Rows
+// below an explicit range are not decoded at all.
+func FromSheet(r io.ReaderAt, size int64, sheet, ref string, maxCells ...int) (array2d.Array2D[any], error) {
+	limit := DefaultMaxCells
+	if len(maxCells) > 0 {
+		limit = maxCells[0]
+	}
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return array2d.Array2D[any]{}, fmt.Errorf("xlsx: %w", err)
+	}
+	wb, err := openWorkbook(zr)
+	if err != nil {
+		return array2d.Array2D[any]{}, err
+	}
+	top, left, bottom, right := 0, 0, -1, -1
+	if ref == "" {
+		ref = "A1"
+	}
+	start, end, isRange := strings.Cut(ref, ":")
+	if top, left, err = parseCell(start); err != nil {
+		return array2d.Array2D[any]{}, err
+	}
+	tooLarge := func(height, width int) error {
+		if width > 0 && height > limit/width {
+			return fmt.Errorf("%w: %dx%d range %s exceeds %d cells", array2d.ErrShape, height, width, ref, limit)
+		}
+		return nil
+	}
+
+	if isRange {
+		if bottom, right, err = parseCell(end); err != nil {
+			return array2d.Array2D[any]{}, err
+		}
+		if bottom < top {
+			top, bottom = bottom, top
+		}
+		if right < left {
+			left, right = right, left
+		}
+		if err := tooLarge(bottom-top+1, right-left+1); err != nil {
+			return array2d.Array2D[any]{}, err
+		}
+		arr := array2d.New[any](bottom-top+1, right-left+1)
+		err := wb.readSheet(sheet, bottom, func(c cell) error {
+			// Cells outside the range report an error from Set, which is ignored.
+			_ = arr.Set(c.row-top, c.col-left, c.value)
+			return nil
+		})
+		if err != nil {
+			return array2d.Array2D[any]{}, err
+		}
+		return arr, nil
+	}
+
+	// The range extends to the last used row and column, so collect the cells
+	// while tracking that extent, failing as soon as it grows too large.
+	var cells []cell
+	err = wb.readSheet(sheet, -1, func(c cell) error {
+		if c.row > bottom {
+			bottom = c.row
+		}
+		if c.col > right {
+			right = c.col
+		}
+		if err := tooLarge(bottom-top+1, right-left+1); err != nil {
+			return err
+		}
+		if c.row >= top && c.col >= left {
+			cells = append(cells, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return array2d.Array2D[any]{}, err
+	}
+	height, width := bottom-top+1, right-left+1
+	if height < 0 {
+		height = 0
+	}
+	if width < 0 {
+		width = 0
+	}
+	arr := array2d.New[any](height, width)
+	for _, c := range cells {
+		_ = arr.Set(c.row-top, c.col-left, c.value)
+	}
+	return arr, nil
+}
+
+// FromSheetStrings is like FromSheet but returns every cell as text: empty
+// cells are empty strings, numbers are formatted in the shortest form that
+// round-trips, and booleans are "TRUE" or "FALSE" as Excel displays them.
+func FromSheetStrings(r io.ReaderAt, size int64, sheet, ref string, maxCells ...int) (array2d.Array2D[string], error) {
+	arr, err := FromSheet(r, size, sheet, ref, maxCells...)
+	if err != nil {
+		return array2d.Array2D[string]{}, err
+	}
+	return array2d.Map(arr, func(v any) string {
+		switch v := v.(type) {
+		case nil:
+			return ""
+		case float64:
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		case bool:
+			if v {
+				return "TRUE"
+			}
+			return "FALSE"
+		}
+		return v.(string)
+	}), nil
+}
+
+// cell is a non-empty worksheet cell.
+type cell struct {
+	row, col int
+	value    any
+}
+
+// workbook gives access to the parts of an opened .xlsx package.
+type workbook struct {
+	zr            *zip.Reader
+	sheets        []xmlSheet
+	targets       map[string]string // relationship id -> part name
+	sharedStrings []string
+}
+
+type xmlSheet struct {
+	Name string `xml:"name,attr"`
+	ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+type xmlRelationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+func openWorkbook(zr *zip.Reader) (*workbook, error) {
+	wb := &workbook{zr: zr, targets: map[string]string{}}
+	var book struct {
+		Sheets []xmlSheet `xml:"sheets>sheet"`
+	}
+	if err := wb.decode("xl/workbook.xml", &book); err != nil {
+		return nil, err
+	}
+	wb.sheets = book.Sheets
+
+	var rels struct {
+		Relationships []xmlRelationship `xml:"Relationship"`
+	}
+	if err := wb.decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	sharedStrings := ""
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		wb.targets[rel.ID] = target
+		if strings.HasSuffix(rel.Type, "/sharedStrings") {
+			sharedStrings = target
+		}
+	}
+
+	if sharedStrings != "" {
+		var sst struct {
+			Items []xmlText `xml:"si"`
+		}
+		if err := wb.decode(sharedStrings, &sst); err != nil {
+			return nil, err
+		}
+		wb.sharedStrings = make([]string, len(sst.Items))
+		for i, item := range sst.Items {
+			wb.sharedStrings[i] = item.String()
+		}
+	}
+	return wb, nil
+}
+
+func (wb *workbook) decode(name string, v any) error {
+	f, err := wb.zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("xlsx: %w", err)
+	}
+	defer f.Close()
+	if err := xml.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("xlsx: decoding %s: %w", name, err)
+	}
+	return nil
+}
+
+// xmlText is rich or plain text, as used by shared strings and inline strings.
+type xmlText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xmlText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, run := range t.Runs {
+		sb.WriteString(run.T)
+	}
+	return sb.String()
+}
+
+// xmlRow is a row of a worksheet's sheetData.
+type xmlRow struct {
+	R     int `xml:"r,attr"`
+	Cells []struct {
+		R      string   `xml:"r,attr"`
+		T      string   `xml:"t,attr"`
+		V      *string  `xml:"v"`
+		Inline *xmlText `xml:"is"`
+	} `xml:"c"`
+}
+
+// readSheet calls visit for each non-empty cell of the named worksheet, in
+// document order, and stops at the first error visit returns. Rows are decoded
+// one at a time, and decoding stops at the first row past lastRow unless
+// lastRow is negative; worksheets list their rows in ascending order.
+func (wb *workbook) readSheet(name string, lastRow int, visit func(cell) error) error {
+	if len(wb.sheets) == 0 {
+		return fmt.Errorf("xlsx: workbook has no worksheets")
+	}
+	sheet := wb.sheets[0]
+	if name != "" {
+		found := false
+		for _, s := range wb.sheets {
+			if s.Name == name {
+				sheet, found = s, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("xlsx: no worksheet named %q", name)
+		}
+	}
+	target, ok := wb.targets[sheet.ID]
+	if !ok {
+		return fmt.Errorf("xlsx: worksheet %q has no part", sheet.Name)
+	}
+	f, err := wb.zr.Open(target)
+	if err != nil {
+		return fmt.Errorf("xlsx: %w", err)
+	}
+	defer f.Close()
+
+	d := xml.NewDecoder(f)
+	row := -1
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("xlsx: decoding %s: %w", target, err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var xr xmlRow
+		if err := d.DecodeElement(&xr, &start); err != nil {
+			return fmt.Errorf("xlsx: decoding %s: %w", target, err)
+		}
+		if xr.R > 0 {
+			row = xr.R - 1
+		} else {
+			row++
+		}
+		if lastRow >= 0 && row > lastRow {
+			return nil
+		}
+		col := -1
+		for _, xc := range xr.Cells {
+			if xc.R != "" {
+				r, c, err := parseCell(xc.R)
+				if err != nil {
+					return err
+				}
+				row, col = r, c
+			} else {
+				col++
+			}
+			value, err := wb.cellValue(xc.T, xc.V, xc.Inline)
+			if err != nil {
+				return fmt.Errorf("xlsx: cell %s: %w", cellName(row, col), err)
+			}
+			if value != nil {
+				if err := visit(cell{row: row, col: col, value: value}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// cellValue converts the raw contents of a cell of the given type.
+func (wb *workbook) cellValue(typ string, v *string, inline *xmlText) (any, error) {
+	if typ == "inlineStr" {
+		if inline == nil {
+			return nil, nil
+		}
+		return inline.String(), nil
+	}
+	if v == nil {
+		return nil, nil
+	}
+	switch typ {
+	case "s":
+		i, err := strconv.Atoi(*v)
+		if err != nil || i < 0 || i >= len(wb.sharedStrings) {
+			return nil, fmt.Errorf("invalid shared string index %q", *v)
+		}
+		return wb.sharedStrings[i], nil
+	case "str", "e", "d":
+		return *v, nil
+	case "b":
+		return *v == "1", nil
+	case "", "n":
+		return strconv.ParseFloat(*v, 64)
+	}
+	return nil, fmt.Errorf("unsupported cell type %q", typ)
+}
+
+const contentTypesXML = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const rootRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookXML = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const workbookRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
@@ -0,0 +1,196 @@
+//go:build go1.18
+// +build go1.18
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/xll-gen/array2d"
+)
+
+func TestRoundTrip(t *testing.T) {
+	arr, _ := array2d.FromJagged(2, 3, [][]any{
+		{1.5, "text <&>", true},
+		{nil, int64(-7), math.NaN()},
+	})
+	var buf bytes.Buffer
+	if err := ToSheet(&buf, arr, "Data", "C4"); err != nil {
+		t.Fatalf("ToSheet() returned an unexpected error: %v", err)
+	}
+	r := bytes.NewReader(buf.Bytes())
+
+	got, err := FromSheet(r, r.Size(), "Data", "C4")
+	if err != nil {
+		t.Fatalf("FromSheet() returned an unexpected error: %v", err)
+	}
+	want := "Array2d[interface {}] 2x3 [[1.5 text <&> true] [<nil> -7 #NUM!]]"
+	if got.String() != want {
+		t.Errorf("want %q, got %q", want, got.String())
+	}
+
+	whole, _ := FromSheet(r, r.Size(), "", "")
+	if whole.Height() != 5 || whole.Width() != 5 {
+		t.Errorf("used range from A1: want 5x5, got %dx%d", whole.Height(), whole.Width())
+	}
+	if v, _ := whole.Get(3, 2); v != 1.5 {
+		t.Errorf("want 1.5 at C4, got %v", v)
+	}
+
+	sub, _ := FromSheet(r, r.Size(), "Data", "E5:D3")
+	want = "Array2d[interface {}] 3x2 [[<nil> <nil>] [text <&> true] [-7 #NUM!]]"
+	if sub.String() != want {
+		t.Errorf("range: want %q, got %q", want, sub.String())
+	}
+}
+
+func TestStrings(t *testing.T) {
+	arr, _ := array2d.FromJagged(2, 2, [][]string{{"a", ""}, {"", "d"}})
+	var buf bytes.Buffer
+	if err := ToSheet(&buf, arr, "", ""); err != nil {
+		t.Fatalf("ToSheet() returned an unexpected error: %v", err)
+	}
+	r := bytes.NewReader(buf.Bytes())
+	got, err := FromSheetStrings(r, r.Size(), "Sheet1", "A1")
+	if err != nil {
+		t.Fatalf("FromSheetStrings() returned an unexpected error: %v", err)
+	}
+	if !array2d.Equal(arr, got) {
+		t.Errorf("want %v, got %v", arr, got)
+	}
+}
+
+// TestSharedStrings reads a workbook laid out the way Excel writes it, with
+// shared and rich-text strings, absolute part names and cells without
+// explicit references.
+func TestSharedStrings(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="First" sheetId="1" r:id="rId1"/><sheet name="Second" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>` +
+			`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>` +
+			`</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>plain</t></si><si><r><t>ri</t></r><r><t>ch</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>1</v></c><c t="s"><v>0</v></c></row>` +
+			`<row><c t="e"><v>#N/A</v></c><c t="str"><f>A1</f><v>rich</v></c></row>` +
+			`</sheetData></worksheet>`,
+	} {
+		f, _ := zw.Create(name)
+		f.Write([]byte(body))
+	}
+	zw.Close()
+	r := bytes.NewReader(buf.Bytes())
+
+	got, err := FromSheet(r, r.Size(), "Second", "")
+	if err != nil {
+		t.Fatalf("FromSheet() returned an unexpected error: %v", err)
+	}
+	want := "Array2d[interface {}] 2x2 [[rich plain] [#N/A rich]]"
+	if got.String() != want {
+		t.Errorf("want %q, got %q", want, got.String())
+	}
+
+	if first, _ := FromSheet(r, r.Size(), "", ""); first.Height() != 0 {
+		t.Errorf("empty first sheet: want no rows, got %v", first)
+	}
+	if _, err := FromSheet(r, r.Size(), "Missing", ""); err == nil {
+		t.Errorf("want error for a missing worksheet")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	arr := array2d.New[any](2, 2)
+	if err := ToSheet(&bytes.Buffer{}, arr, "a/b", ""); err == nil {
+		t.Errorf("want error for an invalid worksheet name")
+	}
+	if err := ToSheet(&bytes.Buffer{}, arr, "", "XFD1"); !errors.Is(err, array2d.ErrOutOfBounds) {
+		t.Errorf("want ErrOutOfBounds past the last column, got %v", err)
+	}
+	for _, ref := range []string{"", "A", "1", "A0", "A+1", "XFE1"} {
+		if _, _, err := parseCell(ref); err == nil {
+			t.Errorf("parseCell(%q): want error", ref)
+		}
+	}
+	for _, tc := range []struct {
+		ref      string
+		row, col int
+	}{{"A1", 0, 0}, {"z26", 25, 25}, {"AA10", 9, 26}, {"XFD1048576", maxRows - 1, maxCols - 1}} {
+		row, col, err := parseCell(tc.ref)
+		if err != nil || row != tc.row || col != tc.col {
+			t.Errorf("parseCell(%q): want (%d, %d), got (%d, %d, %v)", tc.ref, tc.row, tc.col, row, col, err)
+		}
+		if name := cellName(row, col); !strings.EqualFold(name, tc.ref) {
+			t.Errorf("cellName(%d, %d): want %q, got %q", row, col, tc.ref, name)
+		}
+	}
+}
+
+func TestFromSheetMaxCells(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ToSheet(&buf, array2d.NewFilled(1, 1, 1.0), "", "XFD1048576"); err != nil {
+		t.Fatalf("ToSheet() returned an unexpected error: %v", err)
+	}
+	r := bytes.NewReader(buf.Bytes())
+
+	if _, err := FromSheet(r, r.Size(), "", ""); !errors.Is(err, array2d.ErrShape) {
+		t.Errorf("distant cell: want ErrShape, got %v", err)
+	}
+	if _, err := FromSheetStrings(r, r.Size(), "", "A1:XFD1048576"); !errors.Is(err, array2d.ErrShape) {
+		t.Errorf("whole-sheet range: want ErrShape, got %v", err)
+	}
+	if got, err := FromSheet(r, r.Size(), "", "XFD1048576"); err != nil || got.String() != "Array2d[interface {}] 1x1 [[1]]" {
+		t.Errorf("want the single cell, got %v (err=%v)", got, err)
+	}
+	if _, err := FromSheet(r, r.Size(), "", "XFC1048575", 3); !errors.Is(err, array2d.ErrShape) {
+		t.Errorf("2x2 range with maxCells 3: want ErrShape, got %v", err)
+	}
+}
+
+// TestDatesAndEarlyStop reads ISO 8601 date cells and checks that rows past
+// an explicit range are never decoded.
+func TestDatesAndEarlyStop(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Dates" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+		// The third row has an unsupported cell type, so reading it fails.
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="d"><v>2024-02-29T00:00:00</v></c><c r="B1"><v>1</v></c></row>` +
+			`<row r="2"><c r="A2" t="d"><v>2024-03-01</v></c><c r="B2"><v>2</v></c></row>` +
+			`<row r="3"><c r="A3" t="x"><v>3</v></c></row>` +
+			`</sheetData></worksheet>`,
+	} {
+		f, _ := zw.Create(name)
+		f.Write([]byte(body))
+	}
+	zw.Close()
+	r := bytes.NewReader(buf.Bytes())
+
+	got, err := FromSheet(r, r.Size(), "", "A1:B2")
+	if err != nil {
+		t.Fatalf("FromSheet() returned an unexpected error: %v", err)
+	}
+	want := "Array2d[interface {}] 2x2 [[2024-02-29T00:00:00 1] [2024-03-01 2]]"
+	if got.String() != want {
+		t.Errorf("want %q, got %q", want, got.String())
+	}
+	if _, err := FromSheet(r, r.Size(), "", ""); err == nil {
+		t.Errorf("want error for the unsupported cell of an open-ended range")
+	}
+}